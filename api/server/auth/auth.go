@@ -0,0 +1,99 @@
+// Package auth implements pluggable request authentication and a
+// permission-based RBAC layer for the Service. An Authenticator resolves
+// the caller identity from an incoming request; a Policy then decides
+// which permissions that identity's roles grant.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// Principal is the authenticated caller of a request: who they are and
+// which roles they hold.
+type Principal struct {
+	Subject string
+	Roles   []string
+}
+
+// Permission is an action a route can require before running its handler.
+type Permission string
+
+const (
+	// PermissionItemRead is required to read Items.
+	PermissionItemRead Permission = "item:read"
+	// PermissionItemWrite is required to create or update Items.
+	PermissionItemWrite Permission = "item:write"
+	// PermissionItemDelete is required to delete Items.
+	PermissionItemDelete Permission = "item:delete"
+	// PermissionLabelAdmin is required to manage Labels and their
+	// associations with Items.
+	PermissionLabelAdmin Permission = "label:admin"
+)
+
+// Authenticator resolves the Principal making an HTTP request, or returns
+// an error if the request cannot be authenticated.
+type Authenticator interface {
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+// Policy maps role names to the permissions they grant.
+type Policy map[string][]Permission
+
+// LoadPolicy reads a Policy from a JSON file shaped like:
+//
+//	{"admin": ["item:read", "item:write", "item:delete", "label:admin"], "viewer": ["item:read"]}
+func LoadPolicy(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy %q: %w", path, err)
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("parsing policy %q: %w", path, err)
+	}
+	return policy, nil
+}
+
+// Allows reports whether any of roles is granted perm by p.
+func (p Policy) Allows(roles []string, perm Permission) bool {
+	for _, role := range roles {
+		for _, granted := range p[role] {
+			if granted == perm {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type contextKey int
+
+const principalContextKey contextKey = 0
+
+// WithPrincipal returns a copy of ctx carrying principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey, principal)
+}
+
+// FromContext returns the Principal attached to ctx, if any was attached by
+// WithPrincipal.
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey).(Principal)
+	return principal, ok
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>"
+// header, returning "" if the header is absent or malformed.
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || header[:len(prefix)] != prefix {
+		return ""
+	}
+	return header[len(prefix):]
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "testing"
+
+func TestPolicyAllows(t *testing.T) {
+	policy := Policy{
+		"admin":  {PermissionItemRead, PermissionItemWrite, PermissionItemDelete, PermissionLabelAdmin},
+		"viewer": {PermissionItemRead},
+	}
+
+	tests := []struct {
+		name  string
+		roles []string
+		perm  Permission
+		want  bool
+	}{
+		{"granted by single role", []string{"admin"}, PermissionItemWrite, true},
+		{"granted by one of several roles", []string{"viewer", "admin"}, PermissionLabelAdmin, true},
+		{"not granted", []string{"viewer"}, PermissionItemWrite, false},
+		{"unknown role", []string{"nobody"}, PermissionItemRead, false},
+		{"no roles", nil, PermissionItemRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Allows(tt.roles, tt.perm); got != tt.want {
+				t.Fatalf("Allows(%v, %q) = %v, want %v", tt.roles, tt.perm, got, tt.want)
+			}
+		})
+	}
+}
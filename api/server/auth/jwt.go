@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwksRefreshInterval is how often a JWKS-backed JWTAuthenticator re-fetches
+// its key set.
+const jwksRefreshInterval = 10 * time.Minute
+
+// JWTAuthenticator authenticates requests bearing a JWT, verified either
+// with a static HMAC secret (HS256) or against RSA keys fetched from a JWKS
+// endpoint (RS256). The Principal's subject and roles come from the
+// token's "sub" and "roles" claims.
+type JWTAuthenticator struct {
+	secret []byte // set for HS256; mutually exclusive with jwksURL
+
+	jwksURL    string
+	httpClient *http.Client
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey
+}
+
+// NewHS256Authenticator returns a JWTAuthenticator that verifies tokens
+// signed with secret using HS256.
+func NewHS256Authenticator(secret []byte) *JWTAuthenticator {
+	return &JWTAuthenticator{secret: secret}
+}
+
+// NewJWKSAuthenticator returns a JWTAuthenticator that verifies RS256
+// tokens against keys fetched from jwksURL. The key set is refreshed every
+// jwksRefreshInterval until ctx is canceled.
+func NewJWKSAuthenticator(ctx context.Context, jwksURL string) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{
+		jwksURL:    jwksURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		keys:       map[string]*rsa.PublicKey{},
+	}
+	if err := a.refreshKeys(); err != nil {
+		return nil, err
+	}
+
+	go a.refreshForever(ctx)
+	return a, nil
+}
+
+// Authenticate verifies the bearer JWT in the Authorization header and
+// returns the Principal described by its claims.
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return Principal{}, fmt.Errorf("missing bearer token")
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, a.keyFunc); err != nil {
+		return Principal{}, fmt.Errorf("verifying jwt: %w", err)
+	}
+
+	subject, _ := claims["sub"].(string)
+	return Principal{Subject: subject, Roles: rolesClaim(claims)}, nil
+}
+
+// keyFunc resolves the key that should have signed token: the static HS256
+// secret, or an RS256 key from the JWKS cache selected by the token's "kid"
+// header.
+func (a *JWTAuthenticator) keyFunc(token *jwt.Token) (interface{}, error) {
+	if a.secret != nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return a.secret, nil
+	}
+
+	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	a.mu.RLock()
+	key, ok := a.keys[kid]
+	a.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
+
+// rolesClaim reads the "roles" claim as a []string, tolerating its absence.
+func rolesClaim(claims jwt.MapClaims) []string {
+	raw, ok := claims["roles"].([]interface{})
+	if !ok {
+		return nil
+	}
+	roles := make([]string, 0, len(raw))
+	for _, r := range raw {
+		if s, ok := r.(string); ok {
+			roles = append(roles, s)
+		}
+	}
+	return roles
+}
+
+// jwkSet is the subset of the JSON Web Key Set format this package
+// understands: RSA public keys identified by "kid".
+type jwkSet struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// refreshForever re-fetches the JWKS every jwksRefreshInterval until ctx is
+// canceled.
+func (a *JWTAuthenticator) refreshForever(ctx context.Context) {
+	ticker := time.NewTicker(jwksRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := a.refreshKeys(); err != nil {
+				log.Printf("error refreshing JWKS: %s", err)
+			}
+		}
+	}
+}
+
+// refreshKeys fetches and parses the JWKS, replacing the cached key set.
+func (a *JWTAuthenticator) refreshKeys() error {
+	resp, err := a.httpClient.Get(a.jwksURL)
+	if err != nil {
+		return fmt.Errorf("fetching jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("decoding jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		pub, err := parseRSAPublicKey(k.N, k.E)
+		if err != nil {
+			return fmt.Errorf("parsing key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = pub
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.mu.Unlock()
+	return nil
+}
+
+// parseRSAPublicKey decodes a JWK's base64url-encoded modulus (n) and
+// exponent (e) into an *rsa.PublicKey.
+func parseRSAPublicKey(n, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, fmt.Errorf("decoding modulus: %w", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, fmt.Errorf("decoding exponent: %w", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestJWTAuthenticator_HS256(t *testing.T) {
+	secret := []byte("test-secret")
+	a := NewHS256Authenticator(secret)
+
+	token := signHS256(t, secret, jwt.MapClaims{"sub": "alice", "roles": []interface{}{"admin"}})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if principal.Subject != "alice" || len(principal.Roles) != 1 || principal.Roles[0] != "admin" {
+		t.Fatalf("unexpected principal: %+v", principal)
+	}
+}
+
+func TestJWTAuthenticator_HS256_WrongSecret(t *testing.T) {
+	a := NewHS256Authenticator([]byte("test-secret"))
+	token := signHS256(t, []byte("a-different-secret"), jwt.MapClaims{"sub": "alice"})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	if _, err := a.Authenticate(r); err == nil {
+		t.Fatal("expected an error for a token signed with the wrong secret")
+	}
+}
+
+func TestJWTAuthenticator_HS256_MissingBearer(t *testing.T) {
+	a := NewHS256Authenticator([]byte("test-secret"))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if _, err := a.Authenticate(r); err == nil {
+		t.Fatal("expected an error when no bearer token is present")
+	}
+}
+
+func TestJWTAuthenticator_RS256_JWKS(t *testing.T) {
+	key, jwks := generateTestJWKS(t, "key-1")
+	server := newJWKSServer(t, jwks)
+	defer server.Close()
+
+	a, err := NewJWKSAuthenticator(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("NewJWKSAuthenticator: %s", err)
+	}
+
+	token := signRS256(t, key, "key-1", jwt.MapClaims{"sub": "bob", "roles": []interface{}{"viewer"}})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if principal.Subject != "bob" || len(principal.Roles) != 1 || principal.Roles[0] != "viewer" {
+		t.Fatalf("unexpected principal: %+v", principal)
+	}
+}
+
+func TestJWTAuthenticator_RS256_UnknownKeyID(t *testing.T) {
+	key, jwks := generateTestJWKS(t, "key-1")
+	server := newJWKSServer(t, jwks)
+	defer server.Close()
+
+	a, err := NewJWKSAuthenticator(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("NewJWKSAuthenticator: %s", err)
+	}
+
+	token := signRS256(t, key, "key-2", jwt.MapClaims{"sub": "bob"})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	if _, err := a.Authenticate(r); err == nil {
+		t.Fatal("expected an error for a token signed by an unknown key id")
+	}
+}
+
+// TestJWTAuthenticator_RejectsAlgConfusion verifies that an HS256
+// authenticator won't accept an RS256 token (and vice versa), closing off
+// the classic "alg confusion" attack where a verifier's key is reused
+// across signing algorithms.
+func TestJWTAuthenticator_RejectsAlgConfusion(t *testing.T) {
+	hs := NewHS256Authenticator([]byte("test-secret"))
+
+	key, jwks := generateTestJWKS(t, "key-1")
+	server := newJWKSServer(t, jwks)
+	defer server.Close()
+	rs, err := NewJWKSAuthenticator(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("NewJWKSAuthenticator: %s", err)
+	}
+
+	rs256Token := signRS256(t, key, "key-1", jwt.MapClaims{"sub": "bob"})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+rs256Token)
+	if _, err := hs.Authenticate(r); err == nil {
+		t.Fatal("expected the HS256 authenticator to reject an RS256 token")
+	}
+
+	hs256Token := signHS256(t, []byte("test-secret"), jwt.MapClaims{"sub": "alice"})
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+hs256Token)
+	if _, err := rs.Authenticate(r); err == nil {
+		t.Fatal("expected the JWKS-backed authenticator to reject an HS256 token")
+	}
+}
+
+func TestJWTAuthenticator_RefreshKeys(t *testing.T) {
+	_, jwks := generateTestJWKS(t, "key-1")
+	server := newJWKSServer(t, jwks)
+	defer server.Close()
+
+	a, err := NewJWKSAuthenticator(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("NewJWKSAuthenticator: %s", err)
+	}
+
+	newKey, newJWKS := generateTestJWKS(t, "key-2")
+	server.setJWKS(newJWKS)
+
+	if err := a.refreshKeys(); err != nil {
+		t.Fatalf("refreshKeys: %s", err)
+	}
+
+	token := signRS256(t, newKey, "key-2", jwt.MapClaims{"sub": "carol"})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate after refresh: %s", err)
+	}
+	if principal.Subject != "carol" {
+		t.Fatalf("unexpected principal: %+v", principal)
+	}
+}
+
+func signHS256(t *testing.T, secret []byte, claims jwt.MapClaims) string {
+	t.Helper()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatalf("signing HS256 token: %s", err)
+	}
+	return signed
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("signing RS256 token: %s", err)
+	}
+	return signed
+}
+
+// generateTestJWKS returns a fresh RSA key pair and the jwkSet-shaped
+// document exposing its public half under kid.
+func generateTestJWKS(t *testing.T, kid string) (*rsa.PrivateKey, jwkSet) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %s", err)
+	}
+
+	var set jwkSet
+	set.Keys = append(set.Keys, struct {
+		Kid string `json:"kid"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}{
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+	})
+	return key, set
+}
+
+// jwksTestServer is an httptest.Server that serves whatever jwkSet it was
+// last given, letting a test swap out the key set mid-run to exercise
+// JWTAuthenticator's refresh path.
+type jwksTestServer struct {
+	*httptest.Server
+	mu chan jwkSet
+}
+
+func newJWKSServer(t *testing.T, initial jwkSet) *jwksTestServer {
+	t.Helper()
+
+	s := &jwksTestServer{mu: make(chan jwkSet, 1)}
+	s.mu <- initial
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := <-s.mu
+		s.mu <- current
+		if err := json.NewEncoder(w).Encode(current); err != nil {
+			t.Errorf("encoding jwks response: %s", err)
+		}
+	}))
+	return s
+}
+
+func (s *jwksTestServer) setJWKS(set jwkSet) {
+	<-s.mu
+	s.mu <- set
+}
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// MTLSAuthenticator authenticates requests by the subject of the client
+// certificate presented during the TLS handshake. It is only useful when
+// the Service is started with ListenAndServeTLS configured to request and
+// verify client certificates. Roles are looked up by certificate common
+// name in a static table.
+type MTLSAuthenticator struct {
+	roles map[string][]string // certificate common name -> roles
+}
+
+// NewMTLSAuthenticator returns an Authenticator that grants roles to the
+// given certificate common names.
+func NewMTLSAuthenticator(roles map[string][]string) *MTLSAuthenticator {
+	return &MTLSAuthenticator{roles: roles}
+}
+
+// Authenticate extracts the Principal from the verified client certificate
+// on r.TLS.
+func (a *MTLSAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return Principal{}, fmt.Errorf("no client certificate presented")
+	}
+
+	commonName := r.TLS.PeerCertificates[0].Subject.CommonName
+	return Principal{Subject: commonName, Roles: a.roles[commonName]}, nil
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMTLSAuthenticator_Authenticate(t *testing.T) {
+	a := NewMTLSAuthenticator(map[string][]string{"client.example.com": {"admin"}})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{
+			{Subject: pkix.Name{CommonName: "client.example.com"}},
+		},
+	}
+
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if principal.Subject != "client.example.com" || len(principal.Roles) != 1 || principal.Roles[0] != "admin" {
+		t.Fatalf("unexpected principal: %+v", principal)
+	}
+}
+
+func TestMTLSAuthenticator_NoClientCertificate(t *testing.T) {
+	a := NewMTLSAuthenticator(map[string][]string{"client.example.com": {"admin"}})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if _, err := a.Authenticate(r); err == nil {
+		t.Fatal("expected an error when no client certificate was presented")
+	}
+}
+
+func TestMTLSAuthenticator_UnknownCommonName(t *testing.T) {
+	a := NewMTLSAuthenticator(map[string][]string{"client.example.com": {"admin"}})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{
+			{Subject: pkix.Name{CommonName: "stranger.example.com"}},
+		},
+	}
+
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if len(principal.Roles) != 0 {
+		t.Fatalf("expected no roles for an unconfigured common name, got %v", principal.Roles)
+	}
+}
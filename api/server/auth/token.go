@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// TokenPrincipal is one entry of a static token config file: the bearer
+// token mapped to the Principal it authenticates as.
+type TokenPrincipal struct {
+	Token   string   `json:"token"`
+	Subject string   `json:"subject"`
+	Roles   []string `json:"roles"`
+}
+
+// StaticTokenAuthenticator authenticates requests whose Authorization
+// header carries one of a fixed set of bearer tokens loaded from a config
+// file.
+type StaticTokenAuthenticator struct {
+	tokens map[string]Principal
+}
+
+// LoadStaticTokens reads a JSON array of TokenPrincipal from path and
+// returns a StaticTokenAuthenticator backed by it.
+func LoadStaticTokens(path string) (*StaticTokenAuthenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading token config %q: %w", path, err)
+	}
+
+	var entries []TokenPrincipal
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing token config %q: %w", path, err)
+	}
+
+	tokens := make(map[string]Principal, len(entries))
+	for _, e := range entries {
+		tokens[e.Token] = Principal{Subject: e.Subject, Roles: e.Roles}
+	}
+	return &StaticTokenAuthenticator{tokens: tokens}, nil
+}
+
+// Authenticate resolves the bearer token in the Authorization header
+// against the configured token set.
+func (a *StaticTokenAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return Principal{}, fmt.Errorf("missing bearer token")
+	}
+
+	principal, ok := a.tokens[token]
+	if !ok {
+		return Principal{}, fmt.Errorf("unrecognized bearer token")
+	}
+	return principal, nil
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTokenConfig(t *testing.T, entries string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	if err := os.WriteFile(path, []byte(entries), 0o600); err != nil {
+		t.Fatalf("writing token config: %s", err)
+	}
+	return path
+}
+
+func TestStaticTokenAuthenticator_Authenticate(t *testing.T) {
+	path := writeTokenConfig(t, `[{"token": "secret-token", "subject": "alice", "roles": ["admin"]}]`)
+
+	a, err := LoadStaticTokens(path)
+	if err != nil {
+		t.Fatalf("LoadStaticTokens: %s", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer secret-token")
+
+	principal, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if principal.Subject != "alice" || len(principal.Roles) != 1 || principal.Roles[0] != "admin" {
+		t.Fatalf("unexpected principal: %+v", principal)
+	}
+}
+
+func TestStaticTokenAuthenticator_MissingHeader(t *testing.T) {
+	path := writeTokenConfig(t, `[{"token": "secret-token", "subject": "alice", "roles": ["admin"]}]`)
+	a, err := LoadStaticTokens(path)
+	if err != nil {
+		t.Fatalf("LoadStaticTokens: %s", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if _, err := a.Authenticate(r); err == nil {
+		t.Fatal("expected an error for a request with no Authorization header")
+	}
+}
+
+func TestStaticTokenAuthenticator_UnrecognizedToken(t *testing.T) {
+	path := writeTokenConfig(t, `[{"token": "secret-token", "subject": "alice", "roles": ["admin"]}]`)
+	a, err := LoadStaticTokens(path)
+	if err != nil {
+		t.Fatalf("LoadStaticTokens: %s", err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer not-the-right-token")
+	if _, err := a.Authenticate(r); err == nil {
+		t.Fatal("expected an error for an unrecognized token")
+	}
+}
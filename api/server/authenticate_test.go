@@ -0,0 +1,138 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/auth"
+)
+
+// fakeAuthenticator always authenticates as the configured Principal.
+type fakeAuthenticator struct {
+	principal auth.Principal
+	err       error
+}
+
+func (f fakeAuthenticator) Authenticate(r *http.Request) (auth.Principal, error) {
+	return f.principal, f.err
+}
+
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+
+	s, err := NewService("mem://", map[string]Item{})
+	if err != nil {
+		t.Fatalf("NewService: %s", err)
+	}
+	return s
+}
+
+func TestAuthenticate_LegacyFallback(t *testing.T) {
+	s := newTestService(t)
+
+	called := false
+	handler := s.authenticate(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}, auth.PermissionItemRead)
+
+	r := httptest.NewRequest(http.MethodGet, "/item", nil)
+	r.Header.Set("Authorization", "Bearer anything")
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if !called || w.Code != http.StatusOK {
+		t.Fatalf("expected the legacy fallback to allow a request carrying Authorization, got code %d", w.Code)
+	}
+}
+
+func TestAuthenticate_LegacyFallback_NoAuthorizationHeader(t *testing.T) {
+	s := newTestService(t)
+
+	handler := s.authenticate(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run without an Authorization header")
+	}, auth.PermissionItemRead)
+
+	r := httptest.NewRequest(http.MethodGet, "/item", nil)
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
+
+// TestAuthenticate_FailsClosedWithoutPolicy confirms that configuring an
+// Authenticator without ever calling SetPolicy denies every request,
+// rather than granting the authenticated caller every permission.
+func TestAuthenticate_FailsClosedWithoutPolicy(t *testing.T) {
+	s := newTestService(t)
+	s.SetAuthenticator(fakeAuthenticator{principal: auth.Principal{Subject: "alice", Roles: []string{"admin"}}})
+
+	handler := s.authenticate(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run without a configured Policy")
+	}, auth.PermissionItemRead)
+
+	r := httptest.NewRequest(http.MethodGet, "/item", nil)
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 when no Policy is configured, got %d", w.Code)
+	}
+}
+
+func TestAuthenticate_PolicyGrants(t *testing.T) {
+	s := newTestService(t)
+	s.SetAuthenticator(fakeAuthenticator{principal: auth.Principal{Subject: "alice", Roles: []string{"admin"}}})
+	s.SetPolicy(auth.Policy{"admin": {auth.PermissionItemRead}})
+
+	called := false
+	handler := s.authenticate(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}, auth.PermissionItemRead)
+
+	r := httptest.NewRequest(http.MethodGet, "/item", nil)
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if !called || w.Code != http.StatusOK {
+		t.Fatalf("expected the request to be allowed, got code %d", w.Code)
+	}
+}
+
+func TestAuthenticate_PolicyDeniesMissingPermission(t *testing.T) {
+	s := newTestService(t)
+	s.SetAuthenticator(fakeAuthenticator{principal: auth.Principal{Subject: "alice", Roles: []string{"viewer"}}})
+	s.SetPolicy(auth.Policy{"viewer": {auth.PermissionItemRead}})
+
+	handler := s.authenticate(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run for a caller lacking the required permission")
+	}, auth.PermissionItemWrite)
+
+	r := httptest.NewRequest(http.MethodGet, "/item", nil)
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestAuthenticate_RejectsFailedAuthentication(t *testing.T) {
+	s := newTestService(t)
+	s.SetAuthenticator(fakeAuthenticator{err: http.ErrNoCookie})
+	s.SetPolicy(auth.Policy{"admin": {auth.PermissionItemRead}})
+
+	handler := s.authenticate(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run when Authenticate fails")
+	}, auth.PermissionItemRead)
+
+	r := httptest.NewRequest(http.MethodGet, "/item", nil)
+	w := httptest.NewRecorder()
+	handler(w, r)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
@@ -1,39 +1,148 @@
 package server
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"net/http"
+	"net/url"
+	"os"
 	"regexp"
-	"sync"
+	"strconv"
+	"time"
 
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/auth"
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/store/local"
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/store/postgres"
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/store/redis"
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/types"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
-type Item struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Tags        []string `json:"tags"`
-}
+// lockTTL is how long a lock obtained through LockItem is held before the
+// background reaper releases it, matching Terraform's default state lock
+// behavior of expiring abandoned locks rather than holding them forever.
+const lockTTL = 5 * time.Minute
+
+// lockReapInterval is how often the background goroutine sweeps for expired
+// locks.
+const lockReapInterval = 30 * time.Second
+
+// Item is an alias of types.Item so callers outside this package can keep
+// referring to server.Item.
+type Item = types.Item
+
+// Label is an alias of types.Label so callers outside this package can keep
+// referring to server.Label.
+type Label = types.Label
+
+// Store is an alias of types.Store so Store implementations only need to
+// depend on the types package, not on server itself.
+type Store = types.Store
+
 type Service struct {
 	connectionString string
-	items            map[string]Item
-	sync.RWMutex
+	store            Store
+	authenticator    auth.Authenticator
+	policy           auth.Policy
+	revisions        *broadcaster
+}
+
+// SetAuthenticator configures the Authenticator used to resolve the caller
+// of every request. If it is never called, ListenAndServe falls back to
+// the legacy check that the Authorization header is merely non-empty.
+func (s *Service) SetAuthenticator(a auth.Authenticator) {
+	s.authenticator = a
 }
 
-// GetItems returns all of the Items that exist in the server
+// SetPolicy configures the RBAC Policy used to decide whether an
+// authenticated caller's roles grant the permission a route requires. It
+// must be called once an Authenticator is set: an authenticated caller is
+// denied every permission until a Policy is configured, since an RBAC
+// feature that defaults to full access once authentication is merely
+// wired up isn't actually enforcing anything.
+func (s *Service) SetPolicy(p auth.Policy) {
+	s.policy = p
+}
+
+// GetItems returns all of the Items that exist in the server. Repeated
+// ?label=name query parameters filter the result to items carrying every
+// named label (AND semantics).
 func (s *Service) GetItems(w http.ResponseWriter, r *http.Request) {
+	items, err := s.store.List()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-	defer s.RUnlock()
-	s.shuffleItemTags()
-	err := json.NewEncoder(w).Encode(s.items)
+	if names := r.URL.Query()["label"]; len(names) > 0 {
+		items, err = s.filterItemsByLabels(items, names)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	items = shuffleItemTags(items)
+	err = json.NewEncoder(w).Encode(items)
 	if err != nil {
 		log.Println(err)
 	}
 }
 
+// filterItemsByLabels returns the subset of items carrying every label in
+// names, matched by label Name. An unknown label name matches nothing.
+func (s *Service) filterItemsByLabels(items map[string]Item, names []string) (map[string]Item, error) {
+	allLabels, err := s.store.ListLabels()
+	if err != nil {
+		return nil, err
+	}
+
+	wantIDs := make(map[string]bool, len(names))
+	for _, name := range names {
+		found := false
+		for _, label := range allLabels {
+			if label.Name == name {
+				wantIDs[label.ID] = true
+				found = true
+			}
+		}
+		if !found {
+			return map[string]Item{}, nil
+		}
+	}
+
+	out := map[string]Item{}
+	for name, item := range items {
+		labels, err := s.store.ItemLabels(name)
+		if err != nil {
+			return nil, err
+		}
+
+		have := make(map[string]bool, len(labels))
+		for _, label := range labels {
+			have[label.ID] = true
+		}
+
+		matches := true
+		for id := range wantIDs {
+			if !have[id] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			out[name] = item
+		}
+	}
+	return out, nil
+}
+
 // PostItem handles adding a new Item
 func (s *Service) PostItem(w http.ResponseWriter, r *http.Request) {
 	var item Item
@@ -53,15 +162,22 @@ func (s *Service) PostItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.Lock()
-	defer s.Unlock()
-
-	if s.itemExists(item.Name) {
+	_, exists, err := s.store.Get(item.Name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exists {
 		http.Error(w, fmt.Sprintf("item %s already exists", item.Name), http.StatusBadRequest)
 		return
 	}
 
-	s.items[item.Name] = item
+	if err := s.store.Put(item, types.NoVersionCheck, ""); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.revisions.bump()
+
 	log.Printf("added item: %s", item.Name)
 	err = json.NewEncoder(w).Encode(item)
 	if err != nil {
@@ -78,6 +194,11 @@ func (s *Service) PutItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !hasConcurrencyControl(r) {
+		http.Error(w, "PUT requires an X-Lock-ID or If-Match header", http.StatusPreconditionRequired)
+		return
+	}
+
 	var item Item
 	if r.Body == nil {
 		http.Error(w, "Please send a request body", http.StatusBadRequest)
@@ -89,16 +210,29 @@ func (s *Service) PutItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.Lock()
-	defer s.Unlock()
-
-	if !s.itemExists(itemName) {
+	_, exists, err := s.store.Get(itemName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
 		log.Printf("item %s does not exist", itemName)
 		http.Error(w, fmt.Sprintf("item %v does not exist", itemName), http.StatusBadRequest)
 		return
 	}
 
-	s.items[itemName] = item
+	expectVersion, ok := parseIfMatch(r)
+	if !ok {
+		http.Error(w, "If-Match header must be a valid version", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.store.Put(item, expectVersion, r.Header.Get("X-Lock-ID")); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	s.revisions.bump()
+
 	log.Printf("updated item: %s", item.Name)
 	err = json.NewEncoder(w).Encode(item)
 	if err != nil {
@@ -114,17 +248,35 @@ func (s *Service) DeleteItem(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "not found", http.StatusNotFound)
 		return
 	}
-	s.Lock()
-	defer s.Unlock()
 
-	if !s.itemExists(itemName) {
+	if !hasConcurrencyControl(r) {
+		http.Error(w, "DELETE requires an X-Lock-ID or If-Match header", http.StatusPreconditionRequired)
+		return
+	}
+
+	expectVersion, ok := parseIfMatch(r)
+	if !ok {
+		http.Error(w, "If-Match header must be a valid version", http.StatusBadRequest)
+		return
+	}
+
+	_, exists, err := s.store.Get(itemName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
 		http.Error(w, fmt.Sprintf("item %s does not exists", itemName), http.StatusNotFound)
 		return
 	}
 
-	delete(s.items, itemName)
+	if err := s.store.Delete(itemName, expectVersion, r.Header.Get("X-Lock-ID")); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	s.revisions.bump()
 
-	_, err := fmt.Fprintf(w, "Deleted item with name %s", itemName)
+	_, err = fmt.Fprintf(w, "Deleted item with name %s", itemName)
 	if err != nil {
 		log.Println(err)
 	}
@@ -139,91 +291,490 @@ func (s *Service) GetItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.RLock()
-	defer s.RUnlock()
-	s.shuffleItemTags()
-	if !s.itemExists(itemName) {
+	item, exists, err := s.store.Get(itemName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	item = shuffleItemTags(map[string]Item{itemName: item})[itemName]
+	err = json.NewEncoder(w).Encode(item)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+}
+
+// LockItem handles acquiring a lock on an Item so that concurrent writers
+// (e.g. two Terraform runs racing against each other) don't clobber each
+// other's changes. The lock ID returned must be presented via the
+// X-Lock-ID header on subsequent PUT/DELETE calls, or via UnlockItem to
+// release it early.
+func (s *Service) LockItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	itemName := vars["name"]
+
+	_, exists, err := s.store.Get(itemName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	lock, err := s.store.Lock(itemName, uuid.New().String(), lockTTL)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(lock); err != nil {
+		log.Println(err)
+	}
+}
+
+// UnlockItem handles releasing a lock held on an Item before its TTL
+// expires. The caller must present the lock ID it was given by LockItem.
+func (s *Service) UnlockItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	itemName := vars["name"]
+
+	var body struct {
+		ID string `json:"id"`
+	}
+	if r.Body != nil {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := s.store.Unlock(itemName, body.ID); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+}
+
+// PostLabel handles creating a new Label. name, color, and scope are taken
+// from the request body; id and timestamps are assigned by the server.
+func (s *Service) PostLabel(w http.ResponseWriter, r *http.Request) {
+	var label Label
+	if r.Body == nil {
+		http.Error(w, "Please send a request body", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	label.ID = uuid.New().String()
+	label.CreatedAt = now
+	label.UpdatedAt = now
+
+	if err := s.store.PutLabel(label); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("added label: %s", label.ID)
+	if err := json.NewEncoder(w).Encode(label); err != nil {
+		log.Printf("error sending response - %s", err)
+	}
+}
+
+// GetLabel handles retrieving a Label with a specific ID.
+func (s *Service) GetLabel(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	label, exists, err := s.store.GetLabel(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(label); err != nil {
+		log.Println(err)
+	}
+}
+
+// GetLabels handles listing every Label known to the server.
+func (s *Service) GetLabels(w http.ResponseWriter, r *http.Request) {
+	labels, err := s.store.ListLabels()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(labels); err != nil {
+		log.Println(err)
+	}
+}
+
+// PutLabel handles updating a Label with a specific ID, preserving its
+// original CreatedAt.
+func (s *Service) PutLabel(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	existing, exists, err := s.store.GetLabel(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
 		http.Error(w, "not found", http.StatusNotFound)
 		return
 	}
 
-	err := json.NewEncoder(w).Encode(s.items[itemName])
+	var label Label
+	if r.Body == nil {
+		http.Error(w, "Please send a request body", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	label.ID = id
+	label.CreatedAt = existing.CreatedAt
+	label.UpdatedAt = time.Now()
+
+	if err := s.store.PutLabel(label); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("updated label: %s", label.ID)
+	if err := json.NewEncoder(w).Encode(label); err != nil {
+		log.Printf("error sending response - %s", err)
+	}
+}
+
+// DeleteLabel handles removing a Label with a specific ID, cascading to
+// remove every association it has with Items.
+func (s *Service) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if err := s.store.DeleteLabel(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := fmt.Fprintf(w, "Deleted label with id %s", id); err != nil {
+		log.Println(err)
+	}
+}
+
+// GetItemLabels handles listing every Label associated with an Item.
+func (s *Service) GetItemLabels(w http.ResponseWriter, r *http.Request) {
+	itemName := mux.Vars(r)["name"]
+
+	labels, err := s.store.ItemLabels(itemName)
 	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(labels); err != nil {
 		log.Println(err)
+	}
+}
+
+// PostItemLabel handles associating a Label with an Item. The label ID is
+// taken from the {"label_id": "..."} request body.
+func (s *Service) PostItemLabel(w http.ResponseWriter, r *http.Request) {
+	itemName := mux.Vars(r)["name"]
+
+	var body struct {
+		LabelID string `json:"label_id"`
+	}
+	if r.Body == nil {
+		http.Error(w, "Please send a request body", http.StatusBadRequest)
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+
+	_, exists, err := s.store.Get(itemName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		http.Error(w, fmt.Sprintf("item %s does not exist", itemName), http.StatusNotFound)
+		return
+	}
+
+	if err := s.store.AddItemLabel(itemName, body.LabelID); err != nil {
+		if errors.Is(err, types.ErrLabelNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("added label %s to item %s", body.LabelID, itemName)
 }
 
-// itemExists checks if an item exists in or not. Does not lock access to the itemService, expects this to
-// be done by the calling method
-func (s *Service) itemExists(itemName string) bool {
-	if _, ok := s.items[itemName]; ok {
-		return true
+// DeleteItemLabel handles removing the association between an Item and a
+// Label.
+func (s *Service) DeleteItemLabel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	itemName := vars["name"]
+	labelID := vars["id"]
+
+	if err := s.store.RemoveItemLabel(itemName, labelID); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
-	return false
+
+	log.Printf("removed label %s from item %s", labelID, itemName)
+}
+
+// hasConcurrencyControl reports whether r carries either of the headers
+// PutItem/DeleteItem require to guard against a blind overwrite: X-Lock-ID
+// (proving the caller holds the item's lock) or If-Match (proving the
+// caller has seen the item's current version).
+func hasConcurrencyControl(r *http.Request) bool {
+	return r.Header.Get("X-Lock-ID") != "" || r.Header.Get("If-Match") != ""
+}
+
+// parseIfMatch reads the If-Match header as the version the caller expects
+// the item to currently be at. An absent header means "don't check the
+// version", reported as types.NoVersionCheck so it can't be confused with a
+// caller explicitly sending version 0; ok is false when the header is
+// present but not a valid non-negative integer.
+func parseIfMatch(r *http.Request) (version int64, ok bool) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return types.NoVersionCheck, true
+	}
+
+	v, err := strconv.ParseInt(header, 10, 64)
+	if err != nil || v < 0 {
+		return 0, false
+	}
+	return v, true
 }
 
-// suffleItemTags shuffles the order of the tags within each item in the itemService.Does not lock access
-// to the itemService, expects this to be done by the calling method
-func (s *Service) shuffleItemTags() {
-	for _, item := range s.items {
-		for i := range item.Tags {
+// writeStoreError translates the sentinel errors a Store can return into
+// the matching HTTP status code.
+func writeStoreError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, types.ErrVersionMismatch):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, types.ErrLocked):
+		http.Error(w, err.Error(), http.StatusLocked)
+	case errors.Is(err, types.ErrLockMismatch):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, types.ErrNotLocked):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// shuffleItemTags returns a copy of items with the order of each item's tags
+// shuffled. It never mutates the Tags slices in items, which may still be
+// shared with a Store's backing data (e.g. the local store's map) after
+// List/Get has returned.
+func shuffleItemTags(items map[string]Item) map[string]Item {
+	out := make(map[string]Item, len(items))
+	for name, item := range items {
+		tags := make([]string, len(item.Tags))
+		copy(tags, item.Tags)
+		for i := range tags {
 			j := rand.Intn(i + 1)
-			item.Tags[i], item.Tags[j] = item.Tags[j], item.Tags[i]
+			tags[i], tags[j] = tags[j], tags[i]
 		}
+		item.Tags = tags
+		out[name] = item
 	}
+	return out
 }
 
-// Service holds the map of items and provides methods CRUD operations on the map
+// Service holds the configured Store and provides HTTP handlers for CRUD
+// operations on Items.
 
-// NewService returns a Service with a connectionString configured and can be a map of items setup. The items map can be empty,
-// or can contain items
-func NewService(connectionString string, items map[string]Item) *Service {
-	return &Service{
+// NewService returns a Service backed by a Store selected from
+// connectionString. The scheme of connectionString picks the backend:
+// "mem://" (or a bare host:port with no scheme) uses the in-memory local
+// store, "postgres://" uses Postgres, and "redis://" uses Redis. items
+// seeds the local store and is ignored by the other backends.
+func NewService(connectionString string, items map[string]Item) (*Service, error) {
+	s, err := newStore(connectionString, items)
+	if err != nil {
+		return nil, err
+	}
+
+	service := &Service{
 		connectionString: connectionString,
-		items:            items,
+		store:            s,
+		revisions:        newBroadcaster(),
 	}
+	go service.reapLocksForever()
+
+	return service, nil
 }
 
-// // ListenAndServe registers the routes to the server and starts the server on the host:port configured in Service
-func (s *Service) ListenAndServe() error {
+// reapLocksForever periodically reaps expired locks so that a client that
+// crashed or lost its connection while holding a lock doesn't block writers
+// forever.
+func (s *Service) reapLocksForever() {
+	ticker := time.NewTicker(lockReapInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.store.ReapExpiredLocks(); err != nil {
+			log.Printf("error reaping expired locks: %s", err)
+		}
+	}
+}
+
+// newStore builds the Store backend indicated by connectionString's scheme.
+func newStore(connectionString string, items map[string]Item) (Store, error) {
+	u, err := url.Parse(connectionString)
+	if err != nil || u.Scheme == "" || u.Scheme == "mem" {
+		return local.New(items), nil
+	}
+
+	switch u.Scheme {
+	case "postgres", "postgresql":
+		return postgres.New(connectionString)
+	case "redis":
+		return redis.New(connectionString)
+	default:
+		// A bare "host:port" such as "localhost:8080" parses as scheme
+		// "localhost" with opaque "8080".
+		if u.Opaque != "" {
+			return local.New(items), nil
+		}
+		return nil, fmt.Errorf("unsupported store scheme %q", u.Scheme)
+	}
+}
+
+// routes builds the mux.Router wiring every endpoint to its handler. Each
+// handler is wrapped in s.authenticate(), which resolves the caller and
+// checks it against the required permission, and logs(), which logs the
+// method, path, and (once authenticated) the calling subject.
+func (s *Service) routes() *mux.Router {
 	r := mux.NewRouter()
 
-	// Each handler is wrapped in logs() and auth() to log out the method and path and to
-	// ensure that a non-empty Authorization header is present
-	r.HandleFunc("/item", logs(auth(s.PostItem))).Methods("POST")
-	r.HandleFunc("/item", logs(auth(s.GetItems))).Methods("GET")
-	r.HandleFunc("/item/{name}", logs(auth(s.GetItem))).Methods("GET")
-	r.HandleFunc("/item/{name}", logs(auth(s.PutItem))).Methods("PUT")
-	r.HandleFunc("/item/{name}", logs(auth(s.DeleteItem))).Methods("DELETE")
+	r.HandleFunc("/item", s.authenticate(logs(s.PostItem), auth.PermissionItemWrite)).Methods("POST")
+	r.HandleFunc("/item", s.authenticate(logs(s.WatchItems), auth.PermissionItemRead)).Methods("GET").Queries("watch", "1")
+	r.HandleFunc("/item", s.authenticate(logs(s.GetItems), auth.PermissionItemRead)).Methods("GET")
+	r.HandleFunc("/item/{name}", s.authenticate(logs(s.WatchItem), auth.PermissionItemRead)).Methods("GET").Queries("watch", "1")
+	r.HandleFunc("/item/{name}", s.authenticate(logs(s.GetItem), auth.PermissionItemRead)).Methods("GET")
+	r.HandleFunc("/item/{name}", s.authenticate(logs(s.PutItem), auth.PermissionItemWrite)).Methods("PUT")
+	r.HandleFunc("/item/{name}", s.authenticate(logs(s.DeleteItem), auth.PermissionItemDelete)).Methods("DELETE")
+	r.HandleFunc("/item/{name}/lock", s.authenticate(logs(s.LockItem), auth.PermissionItemWrite)).Methods("POST")
+	r.HandleFunc("/item/{name}/unlock", s.authenticate(logs(s.UnlockItem), auth.PermissionItemWrite)).Methods("POST")
+	r.HandleFunc("/item/{name}/labels", s.authenticate(logs(s.GetItemLabels), auth.PermissionItemRead)).Methods("GET")
+	r.HandleFunc("/item/{name}/labels", s.authenticate(logs(s.PostItemLabel), auth.PermissionLabelAdmin)).Methods("POST")
+	r.HandleFunc("/item/{name}/labels/{id}", s.authenticate(logs(s.DeleteItemLabel), auth.PermissionLabelAdmin)).Methods("DELETE")
+	r.HandleFunc("/labels", s.authenticate(logs(s.PostLabel), auth.PermissionLabelAdmin)).Methods("POST")
+	r.HandleFunc("/labels", s.authenticate(logs(s.GetLabels), auth.PermissionItemRead)).Methods("GET")
+	r.HandleFunc("/labels/{id}", s.authenticate(logs(s.GetLabel), auth.PermissionItemRead)).Methods("GET")
+	r.HandleFunc("/labels/{id}", s.authenticate(logs(s.PutLabel), auth.PermissionLabelAdmin)).Methods("PUT")
+	r.HandleFunc("/labels/{id}", s.authenticate(logs(s.DeleteLabel), auth.PermissionLabelAdmin)).Methods("DELETE")
 
+	return r
+}
+
+// ListenAndServe registers the routes to the server and starts the server on the host:port configured in Service
+func (s *Service) ListenAndServe() error {
 	log.Printf("Starting server on %s", s.connectionString)
-	err := http.ListenAndServe(s.connectionString, r)
+	return http.ListenAndServe(s.connectionString, s.routes())
+}
+
+// ListenAndServeTLS registers the routes to the server and starts it on the
+// host:port configured in Service over TLS, requiring and verifying a
+// client certificate signed by a CA in caFile. This is the serving path
+// MTLSAuthenticator depends on: it reads the verified client certificate's
+// subject from r.TLS, which is only populated when ClientAuth is set to
+// tls.RequireAndVerifyClientCert.
+func (s *Service) ListenAndServeTLS(certFile, keyFile, caFile string) error {
+	caCert, err := os.ReadFile(caFile)
 	if err != nil {
-		return err
+		return fmt.Errorf("reading client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return fmt.Errorf("no certificates found in %q", caFile)
+	}
+
+	server := &http.Server{
+		Addr:    s.connectionString,
+		Handler: s.routes(),
+		TLSConfig: &tls.Config{
+			ClientCAs:  pool,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		},
 	}
-	return nil
+
+	log.Printf("Starting TLS server on %s", s.connectionString)
+	return server.ListenAndServeTLS(certFile, keyFile)
 }
 
-// // logs prints the Method and Path to stdout
+// // logs prints the Method, Path, and (if the request has been
+// authenticated) the calling subject to stdout
 func logs(handlerFunc http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		method := r.Method
 		path := r.URL.Path
-		log.Printf("%s %s", method, path)
+		if principal, ok := auth.FromContext(r.Context()); ok {
+			log.Printf("%s %s (subject=%s)", method, path, principal.Subject)
+		} else {
+			log.Printf("%s %s", method, path)
+		}
 		handlerFunc(w, r)
 		return
 	}
 }
 
-// // auth checks that a non-empty authorization header has been sent with the request
-func auth(handlerFunc http.HandlerFunc) http.HandlerFunc {
+// authenticate resolves the caller of a request using s.authenticator and
+// checks it against required using s.policy before running handlerFunc. If
+// s.authenticator hasn't been configured (via SetAuthenticator), it falls
+// back to the legacy check that Authorization is merely non-empty. Once an
+// Authenticator is configured, authenticate fails closed: a request is
+// forbidden unless a Policy has been set (via SetPolicy) and grants the
+// caller's roles the required permission.
+func (s *Service) authenticate(handlerFunc http.HandlerFunc, required auth.Permission) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Header.Get("Authorization") == "" {
-			http.Error(w, "Please supply and Authorization token", http.StatusUnauthorized)
+		if s.authenticator == nil {
+			if r.Header.Get("Authorization") == "" {
+				http.Error(w, "Please supply and Authorization token", http.StatusUnauthorized)
+				return
+			}
+			handlerFunc(w, r)
 			return
 		}
-		handlerFunc(w, r)
-		return
+
+		principal, err := s.authenticator.Authenticate(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if s.policy == nil || !s.policy.Allows(principal.Roles, required) {
+			http.Error(w, fmt.Sprintf("subject %q lacks permission %q", principal.Subject, required), http.StatusForbidden)
+			return
+		}
+
+		handlerFunc(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
 	}
 }
@@ -0,0 +1,248 @@
+// Package local implements an in-memory types.Store backed by a map, the
+// storage behavior the server used before pluggable backends were added.
+package local
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/types"
+)
+
+// Store is an in-memory, mutex-guarded map of Items and their locks.
+type Store struct {
+	mu         sync.RWMutex
+	items      map[string]types.Item
+	locks      map[string]types.Lock
+	labels     map[string]types.Label
+	itemLabels map[string]map[string]bool // item name -> set of label IDs
+}
+
+// New returns a Store seeded with items. items may be nil or empty.
+func New(items map[string]types.Item) *Store {
+	if items == nil {
+		items = map[string]types.Item{}
+	}
+	return &Store{
+		items:      items,
+		locks:      map[string]types.Lock{},
+		labels:     map[string]types.Label{},
+		itemLabels: map[string]map[string]bool{},
+	}
+}
+
+// Get returns the Item with the given name.
+func (s *Store) Get(name string) (types.Item, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	item, ok := s.items[name]
+	return item, ok, nil
+}
+
+// List returns every Item in the store, keyed by name.
+func (s *Store) List() (map[string]types.Item, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]types.Item, len(s.items))
+	for name, item := range s.items {
+		out[name] = item
+	}
+	return out, nil
+}
+
+// Put creates or overwrites the Item with the given name and bumps its
+// Version.
+func (s *Store) Put(item types.Item, expectVersion int64, lockID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkLockLocked(item.Name, lockID); err != nil {
+		return err
+	}
+
+	current := s.items[item.Name]
+	if expectVersion != types.NoVersionCheck && current.Version != expectVersion {
+		return types.ErrVersionMismatch
+	}
+
+	item.Version = current.Version + 1
+	s.items[item.Name] = item
+	return nil
+}
+
+// Delete removes the Item with the given name.
+func (s *Store) Delete(name string, expectVersion int64, lockID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.checkLockLocked(name, lockID); err != nil {
+		return err
+	}
+
+	current, exists := s.items[name]
+	if expectVersion != types.NoVersionCheck && (!exists || current.Version != expectVersion) {
+		return types.ErrVersionMismatch
+	}
+
+	delete(s.items, name)
+	delete(s.locks, name)
+	delete(s.itemLabels, name)
+	return nil
+}
+
+// checkLockLocked returns types.ErrLocked if name is locked by someone other
+// than lockID. Expects s.mu to already be held.
+func (s *Store) checkLockLocked(name string, lockID string) error {
+	lock, ok := s.locks[name]
+	if !ok || time.Now().After(lock.ExpiresAt) {
+		return nil
+	}
+	if lock.ID != lockID {
+		return types.ErrLocked
+	}
+	return nil
+}
+
+// Lock acquires a lock on name for the given ttl.
+func (s *Store) Lock(name string, lockID string, ttl time.Duration) (types.Lock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.locks[name]; ok && time.Now().Before(existing.ExpiresAt) {
+		return types.Lock{}, types.ErrLocked
+	}
+
+	lock := types.Lock{ID: lockID, ItemName: name, ExpiresAt: time.Now().Add(ttl)}
+	s.locks[name] = lock
+	return lock, nil
+}
+
+// Unlock releases the lock on name held by lockID.
+func (s *Store) Unlock(name string, lockID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.locks[name]
+	if !ok || time.Now().After(lock.ExpiresAt) {
+		return types.ErrNotLocked
+	}
+	if lock.ID != lockID {
+		return types.ErrLockMismatch
+	}
+
+	delete(s.locks, name)
+	return nil
+}
+
+// GetLock returns the current lock on name, if any live lock exists.
+func (s *Store) GetLock(name string) (types.Lock, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lock, ok := s.locks[name]
+	if !ok || time.Now().After(lock.ExpiresAt) {
+		return types.Lock{}, false, nil
+	}
+	return lock, true, nil
+}
+
+// ReapExpiredLocks releases every lock whose TTL has elapsed.
+func (s *Store) ReapExpiredLocks() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for name, lock := range s.locks {
+		if now.After(lock.ExpiresAt) {
+			delete(s.locks, name)
+		}
+	}
+	return nil
+}
+
+// PutLabel creates or overwrites the Label with the given ID.
+func (s *Store) PutLabel(label types.Label) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.labels[label.ID] = label
+	return nil
+}
+
+// GetLabel returns the Label with the given ID.
+func (s *Store) GetLabel(id string) (types.Label, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	label, ok := s.labels[id]
+	return label, ok, nil
+}
+
+// ListLabels returns every Label in the store, keyed by ID.
+func (s *Store) ListLabels() (map[string]types.Label, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out := make(map[string]types.Label, len(s.labels))
+	for id, label := range s.labels {
+		out[id] = label
+	}
+	return out, nil
+}
+
+// DeleteLabel removes the Label with the given ID and cascades to remove
+// every association it has with Items.
+func (s *Store) DeleteLabel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.labels, id)
+	for _, ids := range s.itemLabels {
+		delete(ids, id)
+	}
+	return nil
+}
+
+// AddItemLabel associates labelID with the named item.
+func (s *Store) AddItemLabel(itemName, labelID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.labels[labelID]; !ok {
+		return types.ErrLabelNotFound
+	}
+
+	ids, ok := s.itemLabels[itemName]
+	if !ok {
+		ids = map[string]bool{}
+		s.itemLabels[itemName] = ids
+	}
+	ids[labelID] = true
+	return nil
+}
+
+// RemoveItemLabel removes the association between labelID and the named
+// item, if any.
+func (s *Store) RemoveItemLabel(itemName, labelID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.itemLabels[itemName], labelID)
+	return nil
+}
+
+// ItemLabels returns every Label associated with the named item.
+func (s *Store) ItemLabels(itemName string) ([]types.Label, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	labels := make([]types.Label, 0, len(s.itemLabels[itemName]))
+	for id := range s.itemLabels[itemName] {
+		if label, ok := s.labels[id]; ok {
+			labels = append(labels, label)
+		}
+	}
+	return labels, nil
+}
@@ -0,0 +1,404 @@
+// Package postgres implements a types.Store backed by Postgres tables:
+//
+//	CREATE TABLE items (
+//		name        TEXT PRIMARY KEY,
+//		description TEXT,
+//		tags        TEXT[],
+//		version     BIGINT NOT NULL DEFAULT 0
+//	)
+//	CREATE TABLE item_locks (
+//		item_name  TEXT PRIMARY KEY REFERENCES items(name) ON DELETE CASCADE,
+//		lock_id    TEXT NOT NULL,
+//		expires_at TIMESTAMPTZ NOT NULL
+//	)
+//	CREATE TABLE labels (
+//		id         TEXT PRIMARY KEY,
+//		name       TEXT NOT NULL,
+//		color      TEXT,
+//		scope      TEXT NOT NULL,
+//		created_at TIMESTAMPTZ NOT NULL,
+//		updated_at TIMESTAMPTZ NOT NULL
+//	)
+//	CREATE TABLE item_labels (
+//		item_name TEXT NOT NULL REFERENCES items(name) ON DELETE CASCADE,
+//		label_id  TEXT NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+//		PRIMARY KEY (item_name, label_id)
+//	)
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/types"
+	"github.com/lib/pq"
+)
+
+// Store is a types.Store backed by a Postgres database/sql connection.
+type Store struct {
+	db *sql.DB
+}
+
+// New opens a Postgres connection using connectionString and ensures the
+// items and item_locks tables exist.
+func New(connectionString string) (*Store, error) {
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("opening postgres connection: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+
+	const schema = `
+		CREATE TABLE IF NOT EXISTS items (
+			name        TEXT PRIMARY KEY,
+			description TEXT,
+			tags        TEXT[],
+			version     BIGINT NOT NULL DEFAULT 0
+		);
+		CREATE TABLE IF NOT EXISTS item_locks (
+			item_name  TEXT PRIMARY KEY REFERENCES items(name) ON DELETE CASCADE,
+			lock_id    TEXT NOT NULL,
+			expires_at TIMESTAMPTZ NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS labels (
+			id         TEXT PRIMARY KEY,
+			name       TEXT NOT NULL,
+			color      TEXT,
+			scope      TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS item_labels (
+			item_name TEXT NOT NULL REFERENCES items(name) ON DELETE CASCADE,
+			label_id  TEXT NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+			PRIMARY KEY (item_name, label_id)
+		)`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("creating item tables: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Get returns the Item with the given name.
+func (s *Store) Get(name string) (types.Item, bool, error) {
+	var item types.Item
+	var tags pq.StringArray
+
+	row := s.db.QueryRow(`SELECT name, description, tags, version FROM items WHERE name = $1`, name)
+	err := row.Scan(&item.Name, &item.Description, &tags, &item.Version)
+	if err == sql.ErrNoRows {
+		return types.Item{}, false, nil
+	}
+	if err != nil {
+		return types.Item{}, false, fmt.Errorf("getting item %q: %w", name, err)
+	}
+
+	item.Tags = []string(tags)
+	return item, true, nil
+}
+
+// List returns every Item in the table, keyed by name.
+func (s *Store) List() (map[string]types.Item, error) {
+	rows, err := s.db.Query(`SELECT name, description, tags, version FROM items`)
+	if err != nil {
+		return nil, fmt.Errorf("listing items: %w", err)
+	}
+	defer rows.Close()
+
+	items := map[string]types.Item{}
+	for rows.Next() {
+		var item types.Item
+		var tags pq.StringArray
+		if err := rows.Scan(&item.Name, &item.Description, &tags, &item.Version); err != nil {
+			return nil, fmt.Errorf("scanning item: %w", err)
+		}
+		item.Tags = []string(tags)
+		items[item.Name] = item
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing items: %w", err)
+	}
+
+	return items, nil
+}
+
+// Put creates or overwrites the Item with the given name and bumps its
+// Version.
+func (s *Store) Put(item types.Item, expectVersion int64, lockID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("putting item %q: %w", item.Name, err)
+	}
+	defer tx.Rollback()
+
+	var currentVersion int64
+	err = tx.QueryRow(`SELECT version FROM items WHERE name = $1 FOR UPDATE`, item.Name).Scan(&currentVersion)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("putting item %q: %w", item.Name, err)
+	}
+
+	if expectVersion != types.NoVersionCheck && currentVersion != expectVersion {
+		return types.ErrVersionMismatch
+	}
+	if err := checkLockTx(tx, item.Name, lockID); err != nil {
+		return err
+	}
+
+	const stmt = `
+		INSERT INTO items (name, description, tags, version)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE SET description = $2, tags = $3, version = $4`
+	if _, err := tx.Exec(stmt, item.Name, item.Description, pq.StringArray(item.Tags), currentVersion+1); err != nil {
+		return fmt.Errorf("putting item %q: %w", item.Name, err)
+	}
+
+	return tx.Commit()
+}
+
+// Delete removes the Item with the given name.
+func (s *Store) Delete(name string, expectVersion int64, lockID string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("deleting item %q: %w", name, err)
+	}
+	defer tx.Rollback()
+
+	if err := checkLockTx(tx, name, lockID); err != nil {
+		return err
+	}
+
+	if expectVersion != types.NoVersionCheck {
+		var currentVersion int64
+		err := tx.QueryRow(`SELECT version FROM items WHERE name = $1 FOR UPDATE`, name).Scan(&currentVersion)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("deleting item %q: %w", name, err)
+		}
+		if err == sql.ErrNoRows || currentVersion != expectVersion {
+			return types.ErrVersionMismatch
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM items WHERE name = $1`, name); err != nil {
+		return fmt.Errorf("deleting item %q: %w", name, err)
+	}
+
+	return tx.Commit()
+}
+
+// checkLockTx returns types.ErrLocked if name is locked by someone other
+// than lockID.
+func checkLockTx(tx *sql.Tx, name string, lockID string) error {
+	var heldBy string
+	var expiresAt time.Time
+	err := tx.QueryRow(`SELECT lock_id, expires_at FROM item_locks WHERE item_name = $1`, name).Scan(&heldBy, &expiresAt)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("checking lock on %q: %w", name, err)
+	}
+	if time.Now().After(expiresAt) {
+		return nil
+	}
+	if heldBy != lockID {
+		return types.ErrLocked
+	}
+	return nil
+}
+
+// Lock acquires a lock on name for the given ttl.
+func (s *Store) Lock(name string, lockID string, ttl time.Duration) (types.Lock, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return types.Lock{}, fmt.Errorf("locking item %q: %w", name, err)
+	}
+	defer tx.Rollback()
+
+	var heldBy string
+	var expiresAt time.Time
+	err = tx.QueryRow(`SELECT lock_id, expires_at FROM item_locks WHERE item_name = $1 FOR UPDATE`, name).Scan(&heldBy, &expiresAt)
+	if err != nil && err != sql.ErrNoRows {
+		return types.Lock{}, fmt.Errorf("locking item %q: %w", name, err)
+	}
+	if err == nil && time.Now().Before(expiresAt) {
+		return types.Lock{}, types.ErrLocked
+	}
+
+	lock := types.Lock{ID: lockID, ItemName: name, ExpiresAt: time.Now().Add(ttl)}
+	const stmt = `
+		INSERT INTO item_locks (item_name, lock_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (item_name) DO UPDATE SET lock_id = $2, expires_at = $3`
+	if _, err := tx.Exec(stmt, name, lock.ID, lock.ExpiresAt); err != nil {
+		return types.Lock{}, fmt.Errorf("locking item %q: %w", name, err)
+	}
+
+	return lock, tx.Commit()
+}
+
+// Unlock releases the lock on name held by lockID.
+func (s *Store) Unlock(name string, lockID string) error {
+	var heldBy string
+	var expiresAt time.Time
+	err := s.db.QueryRow(`SELECT lock_id, expires_at FROM item_locks WHERE item_name = $1`, name).Scan(&heldBy, &expiresAt)
+	if err == sql.ErrNoRows || (err == nil && time.Now().After(expiresAt)) {
+		return types.ErrNotLocked
+	}
+	if err != nil {
+		return fmt.Errorf("unlocking item %q: %w", name, err)
+	}
+	if heldBy != lockID {
+		return types.ErrLockMismatch
+	}
+
+	if _, err := s.db.Exec(`DELETE FROM item_locks WHERE item_name = $1`, name); err != nil {
+		return fmt.Errorf("unlocking item %q: %w", name, err)
+	}
+	return nil
+}
+
+// GetLock returns the current lock on name, if any live lock exists.
+func (s *Store) GetLock(name string) (types.Lock, bool, error) {
+	lock := types.Lock{ItemName: name}
+	err := s.db.QueryRow(`SELECT lock_id, expires_at FROM item_locks WHERE item_name = $1`, name).Scan(&lock.ID, &lock.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return types.Lock{}, false, nil
+	}
+	if err != nil {
+		return types.Lock{}, false, fmt.Errorf("getting lock on %q: %w", name, err)
+	}
+	if time.Now().After(lock.ExpiresAt) {
+		return types.Lock{}, false, nil
+	}
+	return lock, true, nil
+}
+
+// ReapExpiredLocks releases every lock whose TTL has elapsed.
+func (s *Store) ReapExpiredLocks() error {
+	_, err := s.db.Exec(`DELETE FROM item_locks WHERE expires_at < now()`)
+	if err != nil {
+		return fmt.Errorf("reaping expired locks: %w", err)
+	}
+	return nil
+}
+
+// PutLabel creates or overwrites the Label with the given ID.
+func (s *Store) PutLabel(label types.Label) error {
+	const stmt = `
+		INSERT INTO labels (id, name, color, scope, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			name = $2, color = $3, scope = $4, created_at = $5, updated_at = $6`
+	if _, err := s.db.Exec(stmt, label.ID, label.Name, label.Color, label.Scope, label.CreatedAt, label.UpdatedAt); err != nil {
+		return fmt.Errorf("putting label %q: %w", label.ID, err)
+	}
+	return nil
+}
+
+// GetLabel returns the Label with the given ID.
+func (s *Store) GetLabel(id string) (types.Label, bool, error) {
+	var label types.Label
+	row := s.db.QueryRow(`SELECT id, name, color, scope, created_at, updated_at FROM labels WHERE id = $1`, id)
+	err := row.Scan(&label.ID, &label.Name, &label.Color, &label.Scope, &label.CreatedAt, &label.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return types.Label{}, false, nil
+	}
+	if err != nil {
+		return types.Label{}, false, fmt.Errorf("getting label %q: %w", id, err)
+	}
+	return label, true, nil
+}
+
+// ListLabels returns every Label in the table, keyed by ID.
+func (s *Store) ListLabels() (map[string]types.Label, error) {
+	rows, err := s.db.Query(`SELECT id, name, color, scope, created_at, updated_at FROM labels`)
+	if err != nil {
+		return nil, fmt.Errorf("listing labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := map[string]types.Label{}
+	for rows.Next() {
+		var label types.Label
+		if err := rows.Scan(&label.ID, &label.Name, &label.Color, &label.Scope, &label.CreatedAt, &label.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning label: %w", err)
+		}
+		labels[label.ID] = label
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing labels: %w", err)
+	}
+
+	return labels, nil
+}
+
+// DeleteLabel removes the Label with the given ID. item_labels cascades via
+// its foreign key.
+func (s *Store) DeleteLabel(id string) error {
+	if _, err := s.db.Exec(`DELETE FROM labels WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("deleting label %q: %w", id, err)
+	}
+	return nil
+}
+
+// AddItemLabel associates labelID with the named item.
+func (s *Store) AddItemLabel(itemName, labelID string) error {
+	var exists bool
+	if err := s.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM labels WHERE id = $1)`, labelID).Scan(&exists); err != nil {
+		return fmt.Errorf("checking label %q: %w", labelID, err)
+	}
+	if !exists {
+		return types.ErrLabelNotFound
+	}
+
+	const stmt = `
+		INSERT INTO item_labels (item_name, label_id)
+		VALUES ($1, $2)
+		ON CONFLICT (item_name, label_id) DO NOTHING`
+	if _, err := s.db.Exec(stmt, itemName, labelID); err != nil {
+		return fmt.Errorf("adding label %q to item %q: %w", labelID, itemName, err)
+	}
+	return nil
+}
+
+// RemoveItemLabel removes the association between labelID and the named
+// item, if any.
+func (s *Store) RemoveItemLabel(itemName, labelID string) error {
+	if _, err := s.db.Exec(`DELETE FROM item_labels WHERE item_name = $1 AND label_id = $2`, itemName, labelID); err != nil {
+		return fmt.Errorf("removing label %q from item %q: %w", labelID, itemName, err)
+	}
+	return nil
+}
+
+// ItemLabels returns every Label associated with the named item.
+func (s *Store) ItemLabels(itemName string) ([]types.Label, error) {
+	const query = `
+		SELECT l.id, l.name, l.color, l.scope, l.created_at, l.updated_at
+		FROM labels l
+		JOIN item_labels il ON il.label_id = l.id
+		WHERE il.item_name = $1`
+	rows, err := s.db.Query(query, itemName)
+	if err != nil {
+		return nil, fmt.Errorf("listing labels for item %q: %w", itemName, err)
+	}
+	defer rows.Close()
+
+	var labels []types.Label
+	for rows.Next() {
+		var label types.Label
+		if err := rows.Scan(&label.ID, &label.Name, &label.Color, &label.Scope, &label.CreatedAt, &label.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning label: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing labels for item %q: %w", itemName, err)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,402 @@
+// Package redis implements a types.Store backed by Redis: each Item is
+// stored as a hash at key "item:<name>", with its name tracked in the
+// "items" set so List doesn't require a KEYS scan. Locks are stored as a
+// string key at "lock:<name>" holding the lock ID, set with SETNX and the
+// lock's TTL. Labels are stored as a
+// hash at "label:<id>", with their IDs tracked in the "labels" set, and
+// each item's associated label IDs live in a set at "item-labels:<name>".
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server/types"
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/net/context"
+)
+
+const itemsSetKey = "items"
+const labelsSetKey = "labels"
+
+// Store is a types.Store backed by a Redis client.
+type Store struct {
+	client *redis.Client
+	ctx    context.Context
+}
+
+// New opens a Redis connection using connectionString (a redis:// URL).
+func New(connectionString string) (*Store, error) {
+	opts, err := redis.ParseURL(connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("parsing redis connection string: %w", err)
+	}
+
+	ctx := context.Background()
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("connecting to redis: %w", err)
+	}
+
+	return &Store{client: client, ctx: ctx}, nil
+}
+
+func itemKey(name string) string {
+	return "item:" + name
+}
+
+func lockKey(name string) string {
+	return "lock:" + name
+}
+
+func labelKey(id string) string {
+	return "label:" + id
+}
+
+func itemLabelsKey(name string) string {
+	return "item-labels:" + name
+}
+
+// Get returns the Item with the given name.
+func (s *Store) Get(name string) (types.Item, bool, error) {
+	values, err := s.client.HGetAll(s.ctx, itemKey(name)).Result()
+	if err != nil {
+		return types.Item{}, false, fmt.Errorf("getting item %q: %w", name, err)
+	}
+	if len(values) == 0 {
+		return types.Item{}, false, nil
+	}
+
+	return fromHash(name, values), true, nil
+}
+
+// List returns every Item in the "items" set, keyed by name.
+func (s *Store) List() (map[string]types.Item, error) {
+	names, err := s.client.SMembers(s.ctx, itemsSetKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("listing item names: %w", err)
+	}
+
+	items := make(map[string]types.Item, len(names))
+	for _, name := range names {
+		item, ok, err := s.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			items[name] = item
+		}
+	}
+	return items, nil
+}
+
+// Put creates or overwrites the Item with the given name and bumps its
+// Version. The lock check, version check, and write are wrapped in a
+// WATCH/MULTI transaction on the item's key so that two concurrent Puts
+// racing on the same version can't both pass the check and clobber each
+// other: if the watched key changes between the check and the write,
+// Redis aborts the transaction and Put reports it as a version mismatch.
+func (s *Store) Put(item types.Item, expectVersion int64, lockID string) error {
+	err := s.client.Watch(s.ctx, func(tx *redis.Tx) error {
+		if err := s.checkLock(item.Name, lockID); err != nil {
+			return err
+		}
+
+		current, _, err := s.Get(item.Name)
+		if err != nil {
+			return err
+		}
+		if expectVersion != types.NoVersionCheck && current.Version != expectVersion {
+			return types.ErrVersionMismatch
+		}
+
+		item.Version = current.Version + 1
+
+		_, err = tx.TxPipelined(s.ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(s.ctx, itemKey(item.Name), toHash(item))
+			pipe.SAdd(s.ctx, itemsSetKey, item.Name)
+			return nil
+		})
+		return err
+	}, itemKey(item.Name))
+
+	if errors.Is(err, redis.TxFailedErr) {
+		return types.ErrVersionMismatch
+	}
+	if errors.Is(err, types.ErrLocked) || errors.Is(err, types.ErrVersionMismatch) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("putting item %q: %w", item.Name, err)
+	}
+	return nil
+}
+
+// Delete removes the Item with the given name. Like Put, the lock check,
+// version check, and write are wrapped in a WATCH/MULTI transaction on the
+// item's key so a concurrent write racing against the version check can't
+// slip in unnoticed.
+func (s *Store) Delete(name string, expectVersion int64, lockID string) error {
+	err := s.client.Watch(s.ctx, func(tx *redis.Tx) error {
+		if err := s.checkLock(name, lockID); err != nil {
+			return err
+		}
+
+		if expectVersion != types.NoVersionCheck {
+			current, exists, err := s.Get(name)
+			if err != nil {
+				return err
+			}
+			if !exists || current.Version != expectVersion {
+				return types.ErrVersionMismatch
+			}
+		}
+
+		_, err := tx.TxPipelined(s.ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(s.ctx, itemKey(name))
+			pipe.SRem(s.ctx, itemsSetKey, name)
+			pipe.Del(s.ctx, lockKey(name))
+			pipe.Del(s.ctx, itemLabelsKey(name))
+			return nil
+		})
+		return err
+	}, itemKey(name))
+
+	if errors.Is(err, redis.TxFailedErr) {
+		return types.ErrVersionMismatch
+	}
+	if errors.Is(err, types.ErrLocked) || errors.Is(err, types.ErrVersionMismatch) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("deleting item %q: %w", name, err)
+	}
+	return nil
+}
+
+// checkLock returns types.ErrLocked if name is locked by someone other than
+// lockID.
+func (s *Store) checkLock(name string, lockID string) error {
+	lock, held, err := s.GetLock(name)
+	if err != nil {
+		return err
+	}
+	if held && lock.ID != lockID {
+		return types.ErrLocked
+	}
+	return nil
+}
+
+// Lock acquires a lock on name for the given ttl.
+func (s *Store) Lock(name string, lockID string, ttl time.Duration) (types.Lock, error) {
+	ok, err := s.client.SetNX(s.ctx, lockKey(name), lockID, ttl).Result()
+	if err != nil {
+		return types.Lock{}, fmt.Errorf("locking item %q: %w", name, err)
+	}
+	if !ok {
+		return types.Lock{}, types.ErrLocked
+	}
+
+	return types.Lock{ID: lockID, ItemName: name, ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+// Unlock releases the lock on name held by lockID.
+func (s *Store) Unlock(name string, lockID string) error {
+	held, err := s.client.Get(s.ctx, lockKey(name)).Result()
+	if err == redis.Nil {
+		return types.ErrNotLocked
+	}
+	if err != nil {
+		return fmt.Errorf("unlocking item %q: %w", name, err)
+	}
+	if held != lockID {
+		return types.ErrLockMismatch
+	}
+
+	if err := s.client.Del(s.ctx, lockKey(name)).Err(); err != nil {
+		return fmt.Errorf("unlocking item %q: %w", name, err)
+	}
+	return nil
+}
+
+// GetLock returns the current lock on name, if any live lock exists. Redis'
+// own key TTL means an expired lock is simply absent.
+func (s *Store) GetLock(name string) (types.Lock, bool, error) {
+	id, err := s.client.Get(s.ctx, lockKey(name)).Result()
+	if err == redis.Nil {
+		return types.Lock{}, false, nil
+	}
+	if err != nil {
+		return types.Lock{}, false, fmt.Errorf("getting lock on %q: %w", name, err)
+	}
+
+	ttl, err := s.client.TTL(s.ctx, lockKey(name)).Result()
+	if err != nil {
+		return types.Lock{}, false, fmt.Errorf("getting lock ttl on %q: %w", name, err)
+	}
+
+	return types.Lock{ID: id, ItemName: name, ExpiresAt: time.Now().Add(ttl)}, true, nil
+}
+
+// ReapExpiredLocks is a no-op: Redis expires lock keys itself via their TTL.
+func (s *Store) ReapExpiredLocks() error {
+	return nil
+}
+
+// PutLabel creates or overwrites the Label with the given ID.
+func (s *Store) PutLabel(label types.Label) error {
+	pipe := s.client.TxPipeline()
+	pipe.HSet(s.ctx, labelKey(label.ID), toLabelHash(label))
+	pipe.SAdd(s.ctx, labelsSetKey, label.ID)
+	if _, err := pipe.Exec(s.ctx); err != nil {
+		return fmt.Errorf("putting label %q: %w", label.ID, err)
+	}
+	return nil
+}
+
+// GetLabel returns the Label with the given ID.
+func (s *Store) GetLabel(id string) (types.Label, bool, error) {
+	values, err := s.client.HGetAll(s.ctx, labelKey(id)).Result()
+	if err != nil {
+		return types.Label{}, false, fmt.Errorf("getting label %q: %w", id, err)
+	}
+	if len(values) == 0 {
+		return types.Label{}, false, nil
+	}
+
+	return fromLabelHash(id, values), true, nil
+}
+
+// ListLabels returns every Label in the "labels" set, keyed by ID.
+func (s *Store) ListLabels() (map[string]types.Label, error) {
+	ids, err := s.client.SMembers(s.ctx, labelsSetKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("listing label ids: %w", err)
+	}
+
+	labels := make(map[string]types.Label, len(ids))
+	for _, id := range ids {
+		label, ok, err := s.GetLabel(id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			labels[id] = label
+		}
+	}
+	return labels, nil
+}
+
+// DeleteLabel removes the Label with the given ID and cascades to remove
+// every association it has with Items.
+func (s *Store) DeleteLabel(id string) error {
+	names, err := s.client.SMembers(s.ctx, itemsSetKey).Result()
+	if err != nil {
+		return fmt.Errorf("deleting label %q: %w", id, err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(s.ctx, labelKey(id))
+	pipe.SRem(s.ctx, labelsSetKey, id)
+	for _, name := range names {
+		pipe.SRem(s.ctx, itemLabelsKey(name), id)
+	}
+	if _, err := pipe.Exec(s.ctx); err != nil {
+		return fmt.Errorf("deleting label %q: %w", id, err)
+	}
+	return nil
+}
+
+// AddItemLabel associates labelID with the named item.
+func (s *Store) AddItemLabel(itemName, labelID string) error {
+	exists, err := s.client.SIsMember(s.ctx, labelsSetKey, labelID).Result()
+	if err != nil {
+		return fmt.Errorf("checking label %q: %w", labelID, err)
+	}
+	if !exists {
+		return types.ErrLabelNotFound
+	}
+
+	if err := s.client.SAdd(s.ctx, itemLabelsKey(itemName), labelID).Err(); err != nil {
+		return fmt.Errorf("adding label %q to item %q: %w", labelID, itemName, err)
+	}
+	return nil
+}
+
+// RemoveItemLabel removes the association between labelID and the named
+// item, if any.
+func (s *Store) RemoveItemLabel(itemName, labelID string) error {
+	if err := s.client.SRem(s.ctx, itemLabelsKey(itemName), labelID).Err(); err != nil {
+		return fmt.Errorf("removing label %q from item %q: %w", labelID, itemName, err)
+	}
+	return nil
+}
+
+// ItemLabels returns every Label associated with the named item.
+func (s *Store) ItemLabels(itemName string) ([]types.Label, error) {
+	ids, err := s.client.SMembers(s.ctx, itemLabelsKey(itemName)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("listing labels for item %q: %w", itemName, err)
+	}
+
+	labels := make([]types.Label, 0, len(ids))
+	for _, id := range ids {
+		label, ok, err := s.GetLabel(id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			labels = append(labels, label)
+		}
+	}
+	return labels, nil
+}
+
+func toHash(item types.Item) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        item.Name,
+		"description": item.Description,
+		"tags":        strings.Join(item.Tags, ","),
+		"version":     strconv.FormatInt(item.Version, 10),
+	}
+}
+
+func fromHash(name string, values map[string]string) types.Item {
+	item := types.Item{Name: name, Description: values["description"]}
+	if tags := values["tags"]; tags != "" {
+		item.Tags = strings.Split(tags, ",")
+	}
+	if v, err := strconv.ParseInt(values["version"], 10, 64); err == nil {
+		item.Version = v
+	}
+	return item
+}
+
+func toLabelHash(label types.Label) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       label.Name,
+		"color":      label.Color,
+		"scope":      string(label.Scope),
+		"created_at": label.CreatedAt.Format(time.RFC3339Nano),
+		"updated_at": label.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func fromLabelHash(id string, values map[string]string) types.Label {
+	label := types.Label{
+		ID:    id,
+		Name:  values["name"],
+		Color: values["color"],
+		Scope: types.LabelScope(values["scope"]),
+	}
+	if t, err := time.Parse(time.RFC3339Nano, values["created_at"]); err == nil {
+		label.CreatedAt = t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, values["updated_at"]); err == nil {
+		label.UpdatedAt = t
+	}
+	return label
+}
@@ -0,0 +1,156 @@
+// Package types holds the data types shared between the Service and its
+// Store backends, kept separate so store implementations don't import the
+// server package.
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+// Item is a single named resource managed by the Service. Version is bumped
+// by the Store on every successful Put and is used for optimistic
+// concurrency via the If-Match header.
+type Item struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Version     int64    `json:"version"`
+}
+
+// NoVersionCheck is passed as Put/Delete's expectVersion to mean "the
+// caller didn't ask for a version check", as opposed to a real version
+// number (including 0, which an item without one yet can legitimately be
+// compared against). A real version can never be negative, so it's a safe
+// sentinel.
+const NoVersionCheck int64 = -1
+
+// Lock represents a held lock on an Item, modeled on Terraform's state
+// locking: a caller obtains a Lock ID, must present it on writes, and the
+// lock expires on its own if it's never released.
+type Lock struct {
+	ID        string    `json:"id"`
+	ItemName  string    `json:"item_name"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LabelScope is the scope a Label was created in.
+type LabelScope string
+
+const (
+	// LabelScopeGlobal labels can be applied to any item.
+	LabelScopeGlobal LabelScope = "global"
+	// LabelScopeProject labels are meant for a single project's items.
+	LabelScopeProject LabelScope = "project"
+)
+
+// Label is structured metadata (e.g. environment, owner, cost-center) that
+// can be associated with any number of Items, modeled on Harbor's
+// repository labels.
+type Label struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Color     string     `json:"color"`
+	Scope     LabelScope `json:"scope"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// Errors returned by Store implementations so the Service can translate
+// them into the right HTTP status code.
+var (
+	// ErrVersionMismatch is returned by Put when the caller's expected
+	// version does not match the stored version.
+	ErrVersionMismatch = errors.New("version mismatch")
+
+	// ErrLocked is returned by Lock when the item is already locked by
+	// someone else, and by Put/Delete when a write is attempted without
+	// the current lock ID.
+	ErrLocked = errors.New("item is locked")
+
+	// ErrLockMismatch is returned by Unlock when the given lock ID does
+	// not match the lock currently held on the item.
+	ErrLockMismatch = errors.New("lock id does not match")
+
+	// ErrNotLocked is returned by Unlock when the item has no lock held.
+	ErrNotLocked = errors.New("item is not locked")
+
+	// ErrLabelNotFound is returned by AddItemLabel when the label being
+	// associated does not exist.
+	ErrLabelNotFound = errors.New("label not found")
+)
+
+// Store is implemented by every persistence backend the Service can use to
+// hold Items and their locks. Implementations must be safe for concurrent
+// use.
+type Store interface {
+	// Get returns the Item with the given name. The bool result reports
+	// whether the item was found; it is false (with a nil error) when the
+	// name does not exist.
+	Get(name string) (Item, bool, error)
+
+	// List returns every Item known to the store, keyed by name.
+	List() (map[string]Item, error)
+
+	// Put creates or overwrites the Item with the given name and bumps its
+	// Version. expectVersion is NoVersionCheck (-1) when the caller didn't
+	// ask for a version check, or a version (including 0, the version of an
+	// item that doesn't exist yet) to compare against the currently stored
+	// one, returning ErrVersionMismatch on mismatch. If the item holds a
+	// live lock, Put returns ErrLocked unless lockID matches that lock's ID
+	// (an empty lockID never matches); lockID is not checked when no live
+	// lock is held.
+	Put(item Item, expectVersion int64, lockID string) error
+
+	// Delete removes the Item with the given name. It is not an error to
+	// delete a name that does not exist, unless expectVersion requires
+	// otherwise. expectVersion and lockID are validated the same way as in
+	// Put.
+	Delete(name string, expectVersion int64, lockID string) error
+
+	// Lock acquires a lock on name for the given ttl and returns the Lock
+	// that was created. It returns ErrLocked if the item is already locked
+	// by someone else.
+	Lock(name string, lockID string, ttl time.Duration) (Lock, error)
+
+	// Unlock releases the lock on name held by lockID. It returns
+	// ErrLockMismatch if lockID does not match the current holder and
+	// ErrNotLocked if the item has no lock.
+	Unlock(name string, lockID string) error
+
+	// GetLock returns the current lock on name, if any live (unexpired)
+	// lock exists.
+	GetLock(name string) (Lock, bool, error)
+
+	// ReapExpiredLocks releases every lock whose TTL has elapsed. It is
+	// called periodically by a background goroutine.
+	ReapExpiredLocks() error
+
+	// PutLabel creates or overwrites the Label with the given ID.
+	PutLabel(label Label) error
+
+	// GetLabel returns the Label with the given ID. The bool result reports
+	// whether the label was found; it is false (with a nil error) when the
+	// ID does not exist.
+	GetLabel(id string) (Label, bool, error)
+
+	// ListLabels returns every Label known to the store, keyed by ID.
+	ListLabels() (map[string]Label, error)
+
+	// DeleteLabel removes the Label with the given ID. It is not an error
+	// to delete an ID that does not exist. Deleting a label cascades to
+	// remove every association it has with Items.
+	DeleteLabel(id string) error
+
+	// AddItemLabel associates labelID with the named item. It returns
+	// ErrLabelNotFound if labelID does not exist.
+	AddItemLabel(itemName, labelID string) error
+
+	// RemoveItemLabel removes the association between labelID and the named
+	// item, if any. It is not an error to remove an association that does
+	// not exist.
+	RemoveItemLabel(itemName, labelID string) error
+
+	// ItemLabels returns every Label associated with the named item.
+	ItemLabels(itemName string) ([]Label, error)
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// broadcaster pairs a monotonically increasing revision counter, bumped by
+// every successful write, with a sync.Cond so long-poll watchers can block
+// until the revision advances past the one they last saw.
+type broadcaster struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	revision uint64
+}
+
+// newBroadcaster returns a broadcaster starting at revision 0.
+func newBroadcaster() *broadcaster {
+	b := &broadcaster{}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// bump advances the revision by one and wakes every blocked waitFor call.
+func (b *broadcaster) bump() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.revision++
+	b.cond.Broadcast()
+	return b.revision
+}
+
+// waitFor blocks until the revision is greater than since or cancel is
+// closed, whichever happens first. ok is false if cancel fired first.
+func (b *broadcaster) waitFor(since uint64, cancel <-chan struct{}) (revision uint64, ok bool) {
+	woken := make(chan struct{})
+	defer close(woken)
+	go func() {
+		select {
+		case <-cancel:
+			// Nudge the Cond so the blocked Wait below re-checks and
+			// observes the cancellation.
+			b.mu.Lock()
+			b.cond.Broadcast()
+			b.mu.Unlock()
+		case <-woken:
+		}
+	}()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for b.revision <= since {
+		select {
+		case <-cancel:
+			return 0, false
+		default:
+		}
+		b.cond.Wait()
+	}
+	return b.revision, true
+}
+
+// subscriber tracks the deadline for one blocked watch request, modeled on
+// the deadline handling in gVisor netstack's gonet adapter: a cancel
+// channel that setDeadline arms a *time.Timer to close, swapped under a
+// mutex so re-arming or closing it is always safe even if both race.
+type subscriber struct {
+	mu       sync.Mutex
+	cancelCh chan struct{}
+	timer    *time.Timer
+	closed   bool
+}
+
+// newSubscriber returns a subscriber whose Done channel is open until
+// setDeadline elapses or close is called.
+func newSubscriber() *subscriber {
+	return &subscriber{cancelCh: make(chan struct{})}
+}
+
+// setDeadline arms the subscriber to close its Done channel when deadline
+// is reached. A zero deadline means wait forever. A deadline that has
+// already passed closes it immediately.
+func (s *subscriber) setDeadline(deadline time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	if deadline.IsZero() {
+		return
+	}
+
+	if d := time.Until(deadline); d > 0 {
+		s.timer = time.AfterFunc(d, s.close)
+	} else {
+		s.closeLocked()
+	}
+}
+
+// Done returns the channel that is closed when the deadline elapses or
+// close is called.
+func (s *subscriber) Done() <-chan struct{} {
+	return s.cancelCh
+}
+
+// close closes the Done channel. It is idempotent: the deadline timer and
+// the handler's own deferred cleanup may both call it.
+func (s *subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+}
+
+func (s *subscriber) closeLocked() {
+	if !s.closed {
+		close(s.cancelCh)
+		s.closed = true
+	}
+}
+
+// mergeCancel returns a channel that closes as soon as either a or b does.
+func mergeCancel(a, b <-chan struct{}) <-chan struct{} {
+	out := make(chan struct{})
+	go func() {
+		select {
+		case <-a:
+		case <-b:
+		}
+		close(out)
+	}()
+	return out
+}
+
+// parseRevision reads the "since" query parameter, defaulting to 0 (any
+// change wakes the watch) when absent.
+func parseRevision(raw string) (uint64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(raw, 10, 64)
+}
+
+// parseWatchDeadline reads the "timeout" query parameter as a
+// time.ParseDuration string (e.g. "30s"). An absent parameter means wait
+// forever, reported as the zero time.Time.
+func parseWatchDeadline(r *http.Request) (time.Time, error) {
+	raw := r.URL.Query().Get("timeout")
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timeout %q: %w", raw, err)
+	}
+	return time.Now().Add(d), nil
+}
+
+// awaitChange blocks until the item revision advances past since or the
+// request's watch deadline elapses. ok is false if the deadline had
+// already passed (the caller must not have registered a subscriber) or
+// elapsed while waiting, or if the client disconnected; callers respond
+// with 204 No Content in either case.
+func (s *Service) awaitChange(r *http.Request, since uint64) (revision uint64, ok bool, err error) {
+	deadline, err := parseWatchDeadline(r)
+	if err != nil {
+		return 0, false, err
+	}
+	if !deadline.IsZero() && !time.Now().Before(deadline) {
+		return 0, false, nil
+	}
+
+	sub := newSubscriber()
+	sub.setDeadline(deadline)
+	defer sub.close()
+
+	revision, ok = s.revisions.waitFor(since, mergeCancel(r.Context().Done(), sub.Done()))
+	return revision, ok, nil
+}
+
+// wantsSSE reports whether the client asked for a server-sent events
+// stream via the Accept header.
+func wantsSSE(r *http.Request) bool {
+	return r.Header.Get("Accept") == "text/event-stream"
+}
+
+// WatchItems handles GET /item?watch=1&since=<revision>, blocking until
+// the Items change or the watch deadline elapses, then responding with the
+// current revision and every Item known to the server. A deadline that has
+// already elapsed returns 204 No Content immediately without registering a
+// watcher.
+func (s *Service) WatchItems(w http.ResponseWriter, r *http.Request) {
+	since, err := parseRevision(r.URL.Query().Get("since"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	revision, ok, err := s.awaitChange(r, since)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	items, err := s.store.List()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	items = shuffleItemTags(items)
+
+	payload := struct {
+		Revision uint64          `json:"revision"`
+		Items    map[string]Item `json:"items"`
+	}{Revision: revision, Items: items}
+
+	writeWatchResponse(w, r, payload)
+}
+
+// WatchItem handles GET /item/{name}?watch=1&since=<revision>, blocking
+// until the named Item changes or the watch deadline elapses, then
+// responding with the current revision and the Item's state. Exists is
+// false if the item has been deleted.
+func (s *Service) WatchItem(w http.ResponseWriter, r *http.Request) {
+	itemName := mux.Vars(r)["name"]
+
+	since, err := parseRevision(r.URL.Query().Get("since"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	revision, ok, err := s.awaitChange(r, since)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	item, exists, err := s.store.Get(itemName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if exists {
+		item = shuffleItemTags(map[string]Item{itemName: item})[itemName]
+	}
+
+	payload := struct {
+		Revision uint64 `json:"revision"`
+		Item     Item   `json:"item"`
+		Exists   bool   `json:"exists"`
+	}{Revision: revision, Item: item, Exists: exists}
+
+	writeWatchResponse(w, r, payload)
+}
+
+// writeWatchResponse encodes payload as a single JSON response, or as one
+// server-sent event if the client's Accept header asked for
+// text/event-stream.
+func writeWatchResponse(w http.ResponseWriter, r *http.Request, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	if !wantsSSE(r) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(data); err != nil {
+			log.Println(err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	if _, err := fmt.Fprintf(w, "event: change\ndata: %s\n\n", data); err != nil {
+		log.Println(err)
+		return
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
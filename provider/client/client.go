@@ -0,0 +1,370 @@
+// Package client is a small HTTP client for the launcher item API, used by
+// the Terraform provider's resources and data sources.
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxRetries is how many times a request is retried after a 429 or 5xx
+// response before the client gives up.
+const maxRetries = 4
+
+// ErrNotFound is returned by do (and therefore by GetItem, GetLabel, etc.)
+// when the server responds 404, so callers can tell "doesn't exist" apart
+// from other request failures.
+var ErrNotFound = errors.New("not found")
+
+// Item mirrors the server's Item JSON representation.
+type Item struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Version     int64    `json:"version"`
+}
+
+// Label mirrors the server's Label JSON representation.
+type Label struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	Scope     string    `json:"scope"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AuthConfig selects how a Client authenticates to the server, mirroring
+// the provider's "auth" block.
+type AuthConfig struct {
+	// Type selects the authentication mechanism: "token", "jwt", or "mtls".
+	// An empty Type means "token".
+	Type string
+
+	// Token is the static bearer token sent when Type is "token".
+	Token string
+
+	// TokenURL, ClientID, and ClientSecret are used when Type is "jwt": the
+	// Client obtains and refreshes a bearer token via the OAuth2 client
+	// credentials grant against TokenURL.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+
+	// CertFile, KeyFile, and CAFile are PEM file paths used when Type is
+	// "mtls" to present a client certificate instead of a bearer token.
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+// Client talks to a launcher server over HTTP.
+type Client struct {
+	endpoint   string
+	auth       AuthConfig
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	cachedToken string
+	tokenExpiry time.Time
+}
+
+// New returns a Client configured to talk to endpoint using auth to
+// authenticate every request. If insecure is true, TLS certificate
+// verification is skipped.
+func New(endpoint string, auth AuthConfig, insecure bool) (*Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecure}
+	if auth.Type == "mtls" {
+		cert, err := tls.LoadX509KeyPair(auth.CertFile, auth.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+
+		if auth.CAFile != "" {
+			caCert, err := os.ReadFile(auth.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading CA file: %w", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("no certificates found in %q", auth.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+	}
+
+	return &Client{
+		endpoint: endpoint,
+		auth:     auth,
+		httpClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			Timeout:   30 * time.Second,
+		},
+	}, nil
+}
+
+// GetItem fetches the item with the given name.
+func (c *Client) GetItem(name string) (*Item, error) {
+	var item Item
+	if err := c.do(http.MethodGet, "/item/"+name, nil, &item, nil); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ListItems fetches every item known to the server.
+func (c *Client) ListItems() (map[string]Item, error) {
+	items := map[string]Item{}
+	if err := c.do(http.MethodGet, "/item", nil, &items, nil); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CreateItem creates a new item.
+func (c *Client) CreateItem(item Item) (*Item, error) {
+	var created Item
+	if err := c.do(http.MethodPost, "/item", item, &created, nil); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateItem overwrites the item with the given name, sending version as
+// the If-Match header so the server can reject the write if the item has
+// changed since version was read.
+func (c *Client) UpdateItem(name string, item Item, version int64) (*Item, error) {
+	var updated Item
+	if err := c.do(http.MethodPut, "/item/"+name, item, &updated, ifMatchHeader(version)); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteItem removes the item with the given name, sending version as the
+// If-Match header so the server can reject the delete if the item has
+// changed since version was read.
+func (c *Client) DeleteItem(name string, version int64) error {
+	return c.do(http.MethodDelete, "/item/"+name, nil, nil, ifMatchHeader(version))
+}
+
+// CreateLabel creates a new label.
+func (c *Client) CreateLabel(label Label) (*Label, error) {
+	var created Label
+	if err := c.do(http.MethodPost, "/labels", label, &created, nil); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetLabel fetches the label with the given ID.
+func (c *Client) GetLabel(id string) (*Label, error) {
+	var label Label
+	if err := c.do(http.MethodGet, "/labels/"+id, nil, &label, nil); err != nil {
+		return nil, err
+	}
+	return &label, nil
+}
+
+// UpdateLabel overwrites the label with the given ID.
+func (c *Client) UpdateLabel(id string, label Label) (*Label, error) {
+	var updated Label
+	if err := c.do(http.MethodPut, "/labels/"+id, label, &updated, nil); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteLabel removes the label with the given ID.
+func (c *Client) DeleteLabel(id string) error {
+	return c.do(http.MethodDelete, "/labels/"+id, nil, nil, nil)
+}
+
+// ItemLabels fetches every label associated with the named item.
+func (c *Client) ItemLabels(itemName string) ([]Label, error) {
+	var labels []Label
+	if err := c.do(http.MethodGet, "/item/"+itemName+"/labels", nil, &labels, nil); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// AddItemLabel associates labelID with the named item.
+func (c *Client) AddItemLabel(itemName, labelID string) error {
+	body := struct {
+		LabelID string `json:"label_id"`
+	}{LabelID: labelID}
+	return c.do(http.MethodPost, "/item/"+itemName+"/labels", body, nil, nil)
+}
+
+// RemoveItemLabel removes the association between labelID and the named
+// item.
+func (c *Client) RemoveItemLabel(itemName, labelID string) error {
+	return c.do(http.MethodDelete, "/item/"+itemName+"/labels/"+labelID, nil, nil, nil)
+}
+
+// ifMatchHeader returns the header set that tells the server which version
+// of an item the caller expects, as required by the server's PUT and
+// DELETE handlers for /item/{name}.
+func ifMatchHeader(version int64) map[string]string {
+	return map[string]string{"If-Match": strconv.FormatInt(version, 10)}
+}
+
+// do issues an HTTP request against c.endpoint+path, retrying on 429 and 5xx
+// responses with jittered exponential backoff. out, if non-nil, receives
+// the decoded JSON response body. headers, if non-nil, are set on the
+// request in addition to Authorization and Content-Type.
+func (c *Client) do(method, path string, body interface{}, out interface{}, headers map[string]string) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff(attempt))
+		}
+
+		req, err := http.NewRequest(method, c.endpoint+path, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("building request: %w", err)
+		}
+
+		token, err := c.bearerToken()
+		if err != nil {
+			return fmt.Errorf("obtaining bearer token: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = fmt.Errorf("reading response body: %w", err)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			lastErr = fmt.Errorf("server returned %d: %s", resp.StatusCode, string(respBody))
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", ErrNotFound, string(respBody))
+		}
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("server returned %d: %s", resp.StatusCode, string(respBody))
+		}
+
+		if out != nil && len(respBody) > 0 {
+			if err := json.Unmarshal(respBody, out); err != nil {
+				return fmt.Errorf("decoding response body: %w", err)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
+}
+
+// tokenExpiryBuffer is how far ahead of a cached JWT's expiry the Client
+// proactively refreshes it.
+const tokenExpiryBuffer = 30 * time.Second
+
+// bearerToken returns the token to send as the Authorization header for
+// c.auth.Type, refreshing it first if needed. It returns "" for "mtls",
+// which authenticates via the client certificate instead.
+func (c *Client) bearerToken() (string, error) {
+	switch c.auth.Type {
+	case "", "token":
+		return c.auth.Token, nil
+	case "mtls":
+		return "", nil
+	case "jwt":
+		return c.jwtToken()
+	default:
+		return "", fmt.Errorf("unsupported auth type %q", c.auth.Type)
+	}
+}
+
+// jwtToken returns a cached JWT obtained via the OAuth2 client credentials
+// grant against c.auth.TokenURL, fetching a new one once the cached token
+// is within tokenExpiryBuffer of expiring.
+func (c *Client) jwtToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cachedToken != "" && time.Now().Before(c.tokenExpiry.Add(-tokenExpiryBuffer)) {
+		return c.cachedToken, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.auth.ClientID},
+		"client_secret": {c.auth.ClientSecret},
+	}
+	resp, err := c.httpClient.PostForm(c.auth.TokenURL, form)
+	if err != nil {
+		return "", fmt.Errorf("requesting token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading token response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("token endpoint returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("decoding token response: %w", err)
+	}
+
+	c.cachedToken = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return c.cachedToken, nil
+}
+
+// backoff returns a jittered exponential delay for the given attempt
+// number, starting at ~250ms and doubling each attempt.
+func backoff(attempt int) time.Duration {
+	base := 250 * time.Millisecond * time.Duration(1<<uint(attempt-1))
+	jitter := time.Duration(rand.Int63n(int64(base) / 2))
+	return base + jitter
+}
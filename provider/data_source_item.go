@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/provider/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceItem returns the schema.Resource backing the launcher_item data
+// source.
+func dataSourceItem() *schema.Resource {
+	return &schema.Resource{
+		Description: "Looks up a single launcher item by name.",
+
+		ReadContext: dataSourceItemRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the item to look up.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Human-readable description of the item.",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Free-form tags associated with the item.",
+			},
+		},
+	}
+}
+
+func dataSourceItemRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	name := d.Get("name").(string)
+	item, err := c.GetItem(name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading item %q: %w", name, err))
+	}
+
+	d.SetId(item.Name)
+	return flattenItem(d, item)
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/provider/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceItems returns the schema.Resource backing the launcher_items
+// list data source.
+func dataSourceItems() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists every launcher item known to the server.",
+
+		ReadContext: dataSourceItemsRead,
+
+		Schema: map[string]*schema.Schema{
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Every item known to the server.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceItemsRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	items, err := c.ListItems()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("listing items: %w", err))
+	}
+
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	flattened := make([]map[string]interface{}, 0, len(items))
+	for _, name := range names {
+		item := items[name]
+		flattened = append(flattened, map[string]interface{}{
+			"name":        item.Name,
+			"description": item.Description,
+			"tags":        item.Tags,
+		})
+	}
+
+	if err := d.Set("items", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.UniqueId())
+	return nil
+}
@@ -0,0 +1,139 @@
+// Package provider implements the Terraform provider for launcher: a
+// schema.Provider that manages launcher_item resources against a launcher
+// server over HTTP.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/provider/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Provider returns the launcher Terraform provider.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:        schema.TypeString,
+				Required:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LAUNCHER_ENDPOINT", nil),
+				Description: "Base URL of the launcher server, e.g. https://launcher.example.com.",
+			},
+			"auth_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("LAUNCHER_AUTH_TOKEN", nil),
+				Description:   "Bearer token sent as the Authorization header on every request. Cannot be set together with auth.",
+				ConflictsWith: []string{"auth"},
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip TLS certificate verification when talking to endpoint.",
+			},
+			"auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Authentication configuration. If omitted, auth_token is sent as a static bearer token.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: `Authentication mechanism: "token", "jwt", or "mtls".`,
+						},
+						"token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Static bearer token, used when type is \"token\".",
+						},
+						"token_url": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "OAuth2 token endpoint the provider obtains and refreshes a JWT from, used when type is \"jwt\".",
+						},
+						"client_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "OAuth2 client ID, used when type is \"jwt\".",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "OAuth2 client secret, used when type is \"jwt\".",
+						},
+						"cert_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to a PEM-encoded client certificate, used when type is \"mtls\".",
+						},
+						"key_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to a PEM-encoded client private key, used when type is \"mtls\".",
+						},
+						"ca_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Path to a PEM-encoded CA bundle to verify the server certificate against, used when type is \"mtls\".",
+						},
+					},
+				},
+			},
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"launcher_item":  resourceItem(),
+			"launcher_label": resourceLabel(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"launcher_item":  dataSourceItem(),
+			"launcher_items": dataSourceItems(),
+		},
+		ConfigureContextFunc: configure,
+	}
+}
+
+// configure builds the client.Client used by every resource and data
+// source from the provider block's arguments.
+func configure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	endpoint := d.Get("endpoint").(string)
+	insecure := d.Get("insecure").(bool)
+
+	auth := expandAuth(d)
+
+	c, err := client.New(endpoint, auth, insecure)
+	if err != nil {
+		return nil, diag.FromErr(fmt.Errorf("configuring launcher client: %w", err))
+	}
+	return c, nil
+}
+
+// expandAuth builds the client.AuthConfig from the provider block's "auth"
+// block, falling back to a static token built from auth_token when "auth"
+// is not set.
+func expandAuth(d *schema.ResourceData) client.AuthConfig {
+	raw := d.Get("auth").([]interface{})
+	if len(raw) == 0 {
+		return client.AuthConfig{Type: "token", Token: d.Get("auth_token").(string)}
+	}
+
+	block := raw[0].(map[string]interface{})
+	return client.AuthConfig{
+		Type:         block["type"].(string),
+		Token:        block["token"].(string),
+		TokenURL:     block["token_url"].(string),
+		ClientID:     block["client_id"].(string),
+		ClientSecret: block["client_secret"].(string),
+		CertFile:     block["cert_file"].(string),
+		KeyFile:      block["key_file"].(string),
+		CAFile:       block["ca_file"].(string),
+	}
+}
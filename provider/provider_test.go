@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/api/server"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testAccProviders is the provider factory map used by every acceptance
+// test in this package.
+var testAccProviders = map[string]*schema.Provider{
+	"launcher": Provider(),
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("provider InternalValidate failed: %s", err)
+	}
+}
+
+// startTestServer spins up a launcher server.Service on a free local port
+// and returns its base URL (e.g. "http://127.0.0.1:53921"). The server
+// backs onto the in-memory store and runs for the lifetime of the test.
+func startTestServer(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserving a free port: %s", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	svc, err := server.NewService(addr, map[string]server.Item{})
+	if err != nil {
+		t.Fatalf("creating test service: %s", err)
+	}
+
+	go func() {
+		if err := svc.ListenAndServe(); err != nil {
+			t.Logf("test server stopped: %s", err)
+		}
+	}()
+
+	baseURL := fmt.Sprintf("http://%s", addr)
+	waitForServer(t, baseURL)
+	return baseURL
+}
+
+// waitForServer polls baseURL until it accepts connections or the deadline
+// elapses.
+func waitForServer(t *testing.T, baseURL string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if resp, err := http.Get(baseURL); err == nil {
+			resp.Body.Close()
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("test server at %s never became reachable", baseURL)
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/provider/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceItem returns the schema.Resource backing launcher_item.
+func resourceItem() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a launcher item.",
+
+		CreateContext: resourceItemCreate,
+		ReadContext:   resourceItemRead,
+		UpdateContext: resourceItemUpdate,
+		DeleteContext: resourceItemDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the item. Cannot contain whitespace.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Human-readable description of the item.",
+			},
+			"tags": {
+				// TypeSet so the server's tag-shuffling on read doesn't
+				// produce spurious diffs between plans.
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Free-form tags associated with the item.",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of launcher_label resources associated with the item.",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Version the server currently has the item at, sent as If-Match on update and delete so a change made outside Terraform is caught instead of silently overwritten.",
+			},
+		},
+	}
+}
+
+func resourceItemCreate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	item, err := c.CreateItem(expandItem(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("creating item: %w", err))
+	}
+	d.SetId(item.Name)
+
+	if err := addItemLabels(c, item.Name, d.Get("labels").(*schema.Set).List()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceItemRead(nil, d, meta)
+}
+
+func resourceItemRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	item, err := c.GetItem(d.Id())
+	if errors.Is(err, client.ErrNotFound) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading item %q: %w", d.Id(), err))
+	}
+
+	labels, err := c.ItemLabels(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading labels for item %q: %w", d.Id(), err))
+	}
+
+	if diags := flattenItem(d, item); diags != nil {
+		return diags
+	}
+	if err := d.Set("labels", labelIDs(labels)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceItemUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	item, err := c.UpdateItem(d.Id(), expandItem(d), int64(d.Get("version").(int)))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("updating item %q: %w", d.Id(), err))
+	}
+
+	if d.HasChange("labels") {
+		oldLabels, newLabels := d.GetChange("labels")
+		if err := syncItemLabels(c, item.Name, oldLabels.(*schema.Set), newLabels.(*schema.Set)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceItemRead(nil, d, meta)
+}
+
+func resourceItemDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	if err := c.DeleteItem(d.Id(), int64(d.Get("version").(int))); err != nil {
+		return diag.FromErr(fmt.Errorf("deleting item %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+// expandItem builds a client.Item from the resource's current config.
+func expandItem(d *schema.ResourceData) client.Item {
+	return client.Item{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Tags:        expandTags(d.Get("tags").(*schema.Set).List()),
+	}
+}
+
+// flattenItem writes a client.Item's fields into the resource's state.
+func flattenItem(d *schema.ResourceData, item *client.Item) diag.Diagnostics {
+	if err := d.Set("name", item.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", item.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", item.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", int(item.Version)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandTags(raw []interface{}) []string {
+	tags := make([]string, len(raw))
+	for i, t := range raw {
+		tags[i] = t.(string)
+	}
+	return tags
+}
+
+// addItemLabels associates every label ID in ids with itemName.
+func addItemLabels(c *client.Client, itemName string, ids []interface{}) error {
+	for _, id := range ids {
+		if err := c.AddItemLabel(itemName, id.(string)); err != nil {
+			return fmt.Errorf("associating label %q with item %q: %w", id, itemName, err)
+		}
+	}
+	return nil
+}
+
+// syncItemLabels reconciles itemName's associated labels from oldSet to
+// newSet, adding labels present only in newSet and removing labels present
+// only in oldSet.
+func syncItemLabels(c *client.Client, itemName string, oldSet, newSet *schema.Set) error {
+	for _, id := range newSet.Difference(oldSet).List() {
+		if err := c.AddItemLabel(itemName, id.(string)); err != nil {
+			return fmt.Errorf("associating label %q with item %q: %w", id, itemName, err)
+		}
+	}
+	for _, id := range oldSet.Difference(newSet).List() {
+		if err := c.RemoveItemLabel(itemName, id.(string)); err != nil {
+			return fmt.Errorf("removing label %q from item %q: %w", id, itemName, err)
+		}
+	}
+	return nil
+}
+
+// labelIDs extracts the IDs from a slice of client.Label.
+func labelIDs(labels []client.Label) []string {
+	ids := make([]string, len(labels))
+	for i, label := range labels {
+		ids[i] = label.ID
+	}
+	return ids
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceItem_basic(t *testing.T) {
+	endpoint := startTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceItemConfig(endpoint, "widget", "a widget", []string{"prod", "team-a"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("launcher_item.test", "name", "widget"),
+					resource.TestCheckResourceAttr("launcher_item.test", "description", "a widget"),
+					resource.TestCheckResourceAttr("launcher_item.test", "tags.#", "2"),
+				),
+			},
+			{
+				Config: testAccResourceItemConfig(endpoint, "widget", "an updated widget", []string{"prod"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("launcher_item.test", "description", "an updated widget"),
+					resource.TestCheckResourceAttr("launcher_item.test", "tags.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceItemConfig(endpoint, name, description string, tags []string) string {
+	return fmt.Sprintf(`
+provider "launcher" {
+  endpoint   = %q
+  auth_token = "test-token"
+}
+
+resource "launcher_item" "test" {
+  name        = %q
+  description = %q
+  tags        = %s
+}
+`, endpoint, name, description, quoteList(tags))
+}
+
+func quoteList(values []string) string {
+	out := "["
+	for i, v := range values {
+		if i > 0 {
+			out += ", "
+		}
+		out += fmt.Sprintf("%q", v)
+	}
+	return out + "]"
+}
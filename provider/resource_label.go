@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RaizadaHaroon/terraform-provider-launcher/provider/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceLabel returns the schema.Resource backing launcher_label.
+func resourceLabel() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a launcher label.",
+
+		CreateContext: resourceLabelCreate,
+		ReadContext:   resourceLabelRead,
+		UpdateContext: resourceLabelUpdate,
+		DeleteContext: resourceLabelDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the label.",
+			},
+			"color": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Display color of the label, e.g. a hex code.",
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "global",
+				Description: "Scope the label applies to: \"global\" or \"project\".",
+			},
+		},
+	}
+}
+
+func resourceLabelCreate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	label, err := c.CreateLabel(expandLabel(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("creating label: %w", err))
+	}
+
+	d.SetId(label.ID)
+	return flattenLabel(d, label)
+}
+
+func resourceLabelRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	label, err := c.GetLabel(d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading label %q: %w", d.Id(), err))
+	}
+
+	return flattenLabel(d, label)
+}
+
+func resourceLabelUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	label, err := c.UpdateLabel(d.Id(), expandLabel(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("updating label %q: %w", d.Id(), err))
+	}
+
+	return flattenLabel(d, label)
+}
+
+func resourceLabelDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client.Client)
+
+	if err := c.DeleteLabel(d.Id()); err != nil {
+		return diag.FromErr(fmt.Errorf("deleting label %q: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+// expandLabel builds a client.Label from the resource's current config.
+func expandLabel(d *schema.ResourceData) client.Label {
+	return client.Label{
+		Name:  d.Get("name").(string),
+		Color: d.Get("color").(string),
+		Scope: d.Get("scope").(string),
+	}
+}
+
+// flattenLabel writes a client.Label's fields into the resource's state.
+func flattenLabel(d *schema.ResourceData, label *client.Label) diag.Diagnostics {
+	if err := d.Set("name", label.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("color", label.Color); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("scope", label.Scope); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
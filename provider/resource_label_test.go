@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceLabel_basic(t *testing.T) {
+	endpoint := startTestServer(t)
+
+	resource.Test(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceLabelConfig(endpoint, "env", "#ff0000", "global"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("launcher_label.test", "name", "env"),
+					resource.TestCheckResourceAttr("launcher_label.test", "color", "#ff0000"),
+					resource.TestCheckResourceAttr("launcher_label.test", "scope", "global"),
+				),
+			},
+			{
+				Config: testAccResourceLabelConfig(endpoint, "env", "#00ff00", "project"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("launcher_label.test", "color", "#00ff00"),
+					resource.TestCheckResourceAttr("launcher_label.test", "scope", "project"),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceLabelConfig(endpoint, name, color, scope string) string {
+	return fmt.Sprintf(`
+provider "launcher" {
+  endpoint   = %q
+  auth_token = "test-token"
+}
+
+resource "launcher_label" "test" {
+  name  = %q
+  color = %q
+  scope = %q
+}
+`, endpoint, name, color, scope)
+}